@@ -1,6 +1,7 @@
 package basic
 
 import (
+	"go-error-handling/internal/errtest"
 	"testing"
 )
 
@@ -80,3 +81,10 @@ func TestDivide_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestDivide_NoAllocOnSuccess(t *testing.T) {
+	errtest.AssertNoAllocOnSuccess(t, func() error {
+		_, err := Divide(10, 2)
+		return err
+	})
+}
@@ -0,0 +1,104 @@
+// Package catalog lists every error this module can return, with its
+// message, HTTP status and retryability, as JSON or CSV for API
+// documentation tooling. It reads the validation code registry
+// (custom.CodeTable) and the sentinel registry (utils.SentinelIDs), so
+// codes and sentinels registered by other packages appear once those
+// packages are imported.
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/utils"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Entry types.
+const (
+	TypeValidation = "validation"
+	TypeSentinel   = "sentinel"
+)
+
+// Entry describes one error code or sentinel.
+type Entry struct {
+	Type string `json:"type"`
+	// ID is the numeric code of a validation error or the "namespace.name"
+	// ID of a sentinel.
+	ID         string `json:"id"`
+	Domain     string `json:"domain"`
+	Canonical  string `json:"canonical,omitempty"`
+	Message    string `json:"message"`
+	HTTPStatus int    `json:"http_status"`
+	Retryable  bool   `json:"retryable"`
+	DocsURL    string `json:"docs_url,omitempty"`
+}
+
+// Build returns the catalog: validation codes in ascending order, then
+// sentinels sorted by ID.
+func Build() []Entry {
+	var entries []Entry
+	for _, row := range custom.CodeTable() {
+		entries = append(entries, Entry{
+			Type:       TypeValidation,
+			ID:         strconv.Itoa(row.Code),
+			Domain:     row.Domain,
+			Canonical:  row.Canonical,
+			Message:    row.Description,
+			HTTPStatus: utils.HTTPStatus(&custom.ValidationError{Code: row.Code}),
+		})
+	}
+	for _, id := range utils.SentinelIDs() {
+		sentinel, _ := utils.Lookup(id)
+		namespace, _, _ := strings.Cut(id, ".")
+		entry := Entry{
+			Type:       TypeSentinel,
+			ID:         id,
+			Domain:     namespace,
+			Message:    sentinel.Error(),
+			HTTPStatus: utils.HTTPStatus(sentinel),
+		}
+		if code := utils.CodeOf(sentinel); code != utils.CodeUnknown {
+			entry.Canonical = code.String()
+		}
+		if meta, ok := utils.Meta(sentinel); ok {
+			entry.Retryable = meta.Retryable
+			entry.DocsURL = meta.DocsURL
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// WriteJSON writes entries as an indented JSON array.
+func WriteJSON(w io.Writer, entries []Entry) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(entries); err != nil {
+		return fmt.Errorf("failed to encode catalog: %w", err)
+	}
+	return nil
+}
+
+// WriteCSV writes one row per entry under a header matching the JSON keys.
+func WriteCSV(w io.Writer, entries []Entry) error {
+	writer := csv.NewWriter(w)
+	header := []string{"type", "id", "domain", "canonical", "message", "http_status", "retryable", "docs_url"}
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+	for _, e := range entries {
+		row := []string{e.Type, e.ID, e.Domain, e.Canonical, e.Message, strconv.Itoa(e.HTTPStatus), strconv.FormatBool(e.Retryable), e.DocsURL}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write csv row for %s: %w", e.ID, err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package catalog
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+)
+
+func findEntry(entries []Entry, id string) (Entry, bool) {
+	for _, e := range entries {
+		if e.ID == id {
+			return e, true
+		}
+	}
+	return Entry{}, false
+}
+
+func TestBuild(t *testing.T) {
+	entries := Build()
+
+	tests := []struct {
+		id       string
+		expected Entry
+	}{
+		{"4001", Entry{Type: TypeValidation, ID: "4001", Domain: "custom", Canonical: "REQUIRED", Message: "required field is missing", HTTPStatus: http.StatusUnprocessableEntity}},
+		{"user.not_found", Entry{Type: TypeSentinel, ID: "user.not_found", Domain: "user", Canonical: "USER_NOT_FOUND", Message: "user not found", HTTPStatus: http.StatusNotFound, DocsURL: "https://github.com/anwarul/go-error-handling/problems/user-not-found"}},
+		{"database.timeout", Entry{Type: TypeSentinel, ID: "database.timeout", Domain: "database", Canonical: "DATABASE_TIMEOUT", Message: "database operation timed out", HTTPStatus: http.StatusGatewayTimeout, Retryable: true, DocsURL: "https://github.com/anwarul/go-error-handling/problems/database-timeout"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			got, ok := findEntry(entries, tt.id)
+			if !ok || got != tt.expected {
+				t.Errorf("Build() entry %s = %+v; want %+v", tt.id, got, tt.expected)
+			}
+		})
+	}
+
+	if last := entries[len(entries)-1]; last.Type != TypeSentinel {
+		t.Errorf("Build() ends with %+v; want validation codes before sentinels", last)
+	}
+}
+
+func TestWriteJSON(t *testing.T) {
+	entries := Build()
+	var buf bytes.Buffer
+	if err := WriteJSON(&buf, entries); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+
+	var decoded []Entry
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("WriteJSON() output is not JSON: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, entries) {
+		t.Errorf("WriteJSON() round trip = %+v; want %+v", decoded, entries)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	entries := Build()
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, entries); err != nil {
+		t.Fatalf("WriteCSV() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("WriteCSV() output is not CSV: %v", err)
+	}
+	if len(records) != len(entries)+1 {
+		t.Fatalf("WriteCSV() wrote %d records; want a header and %d rows", len(records), len(entries))
+	}
+	expectedHeader := []string{"type", "id", "domain", "canonical", "message", "http_status", "retryable", "docs_url"}
+	if !reflect.DeepEqual(records[0], expectedHeader) {
+		t.Errorf("header = %v; want %v", records[0], expectedHeader)
+	}
+}
@@ -0,0 +1,36 @@
+// Command catalog prints the error catalog for API documentation tooling.
+//
+// Usage:
+//
+//	go run ./cmd/catalog [-format json|csv]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"go-error-handling/catalog"
+	"os"
+
+	// Imported for the codes their init functions register.
+	_ "go-error-handling/example"
+	_ "go-error-handling/user"
+)
+
+func main() {
+	format := flag.String("format", "json", "output format: json or csv")
+	flag.Parse()
+
+	var err error
+	switch entries := catalog.Build(); *format {
+	case "json":
+		err = catalog.WriteJSON(os.Stdout, entries)
+	case "csv":
+		err = catalog.WriteCSV(os.Stdout, entries)
+	default:
+		err = fmt.Errorf("unknown format %q", *format)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "catalog:", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,34 @@
+package custom
+
+// AllValidationErrors collects every *ValidationError in err's tree, walking
+// single wrappers and joined errors depth-first.
+func AllValidationErrors(err error) []*ValidationError {
+	var found []*ValidationError
+	collectValidationErrors(err, &found)
+	return found
+}
+
+// OrderedValidationErrors is AllValidationErrors with an explicit ordering
+// guarantee: errors appear in the order they were joined, left to right,
+// with nested joins expanded in place. UI rendering and snapshot tests can
+// rely on this order.
+func OrderedValidationErrors(err error) []*ValidationError {
+	return AllValidationErrors(err)
+}
+
+func collectValidationErrors(err error, found *[]*ValidationError) {
+	if err == nil {
+		return
+	}
+	if ve, ok := err.(*ValidationError); ok {
+		*found = append(*found, ve)
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		collectValidationErrors(x.Unwrap(), found)
+	case interface{ Unwrap() []error }:
+		for _, child := range x.Unwrap() {
+			collectValidationErrors(child, found)
+		}
+	}
+}
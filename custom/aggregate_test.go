@@ -0,0 +1,58 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestOrderedValidationErrors_JoinOrder(t *testing.T) {
+	first := &ValidationError{Field: "Name", Code: 1}
+	second := &ValidationError{Field: "Age", Code: 2}
+	third := &ValidationError{Field: "Email", Code: 3}
+
+	err := errors.Join(first, second, third)
+
+	result := OrderedValidationErrors(err)
+	expected := []*ValidationError{first, second, third}
+	assertSameValidationErrors(t, result, expected)
+}
+
+func TestOrderedValidationErrors_NestedJoin(t *testing.T) {
+	a := &ValidationError{Field: "A", Code: 1}
+	b := &ValidationError{Field: "B", Code: 2}
+	c := &ValidationError{Field: "C", Code: 3}
+	d := &ValidationError{Field: "D", Code: 4}
+
+	err := errors.Join(
+		a,
+		fmt.Errorf("nested: %w", errors.Join(b, c)),
+		errors.New("not a validation error"),
+		d,
+	)
+
+	result := OrderedValidationErrors(err)
+	expected := []*ValidationError{a, b, c, d}
+	assertSameValidationErrors(t, result, expected)
+}
+
+func TestAllValidationErrors_NoneFound(t *testing.T) {
+	if result := AllValidationErrors(errors.New("plain")); len(result) != 0 {
+		t.Errorf("AllValidationErrors(plain) = %v; want empty", result)
+	}
+	if result := AllValidationErrors(nil); len(result) != 0 {
+		t.Errorf("AllValidationErrors(nil) = %v; want empty", result)
+	}
+}
+
+func assertSameValidationErrors(t *testing.T, result, expected []*ValidationError) {
+	t.Helper()
+	if len(result) != len(expected) {
+		t.Fatalf("got %d validation errors; want %d", len(result), len(expected))
+	}
+	for i := range expected {
+		if result[i] != expected[i] {
+			t.Errorf("position %d = %s; want %s", i, result[i].Field, expected[i].Field)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrIncompleteValidation is returned by Build when the field or code is
+// missing; both are part of the client contract and have no safe default.
+var ErrIncompleteValidation = errors.New("validation error requires a field and a non-zero code")
+
+// ValidationBuilder assembles a ValidationError step by step so call sites
+// name each part explicitly instead of relying on struct literal order.
+type ValidationBuilder struct {
+	err ValidationError
+}
+
+// NewValidation starts a builder for the given field.
+func NewValidation(field string) *ValidationBuilder {
+	return &ValidationBuilder{err: ValidationError{Field: field}}
+}
+
+func (b *ValidationBuilder) Code(code int) *ValidationBuilder {
+	b.err.Code = code
+	return b
+}
+
+func (b *ValidationBuilder) Msg(message string) *ValidationBuilder {
+	b.err.Message = message
+	return b
+}
+
+func (b *ValidationBuilder) Hint(hint string) *ValidationBuilder {
+	b.err.Hint = hint
+	return b
+}
+
+func (b *ValidationBuilder) Constraint(key string, value any) *ValidationBuilder {
+	if b.err.Constraints == nil {
+		b.err.Constraints = make(map[string]any)
+	}
+	b.err.Constraints[key] = value
+	return b
+}
+
+func (b *ValidationBuilder) Cause(err error) *ValidationBuilder {
+	b.err.Err = err
+	return b
+}
+
+func (b *ValidationBuilder) Value(value interface{}) *ValidationBuilder {
+	b.err.Value = value
+	return b
+}
+
+// Build returns the assembled error. A missing message defaults to
+// "<field> is invalid"; a missing field or code is reported instead of
+// producing an error clients cannot act on.
+func (b *ValidationBuilder) Build() (*ValidationError, error) {
+	if b.err.Field == "" || b.err.Code == 0 {
+		return nil, fmt.Errorf("build validation error for field %q with code %d: %w",
+			b.err.Field, b.err.Code, ErrIncompleteValidation)
+	}
+	built := b.err
+	// Copy constraints so later builder calls don't leak into this error
+	if b.err.Constraints != nil {
+		built.Constraints = make(map[string]any, len(b.err.Constraints))
+		for k, v := range b.err.Constraints {
+			built.Constraints[k] = v
+		}
+	}
+	if built.Message == "" {
+		built.Message = built.Field + " is invalid"
+	}
+	return &built, nil
+}
@@ -0,0 +1,64 @@
+package custom
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidationBuilder_Build(t *testing.T) {
+	err, buildErr := NewValidation("value").Code(1001).Msg("Value cannot be negative").Value(-5).Build()
+	if buildErr != nil {
+		t.Fatalf("Build() returned unexpected error: %v", buildErr)
+	}
+
+	expected := ValidationError{Field: "value", Message: "Value cannot be negative", Code: 1001, Value: -5}
+	if !reflect.DeepEqual(*err, expected) {
+		t.Errorf("Build() = %+v; want %+v", *err, expected)
+	}
+}
+
+func TestValidationBuilder_Defaults(t *testing.T) {
+	err, buildErr := NewValidation("Email").Code(2003).Build()
+	if buildErr != nil {
+		t.Fatalf("Build() returned unexpected error: %v", buildErr)
+	}
+	if err.Message != "Email is invalid" {
+		t.Errorf("Build() default message = %q; want %q", err.Message, "Email is invalid")
+	}
+	if err.Value != nil {
+		t.Errorf("Build() default value = %v; want nil", err.Value)
+	}
+}
+
+func TestValidationBuilder_Incomplete(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *ValidationBuilder
+	}{
+		{"missing code", NewValidation("Age").Msg("Age cannot be negative")},
+		{"missing field", NewValidation("").Code(2001)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err, buildErr := tt.builder.Build()
+			if err != nil {
+				t.Errorf("Build() = %v; want nil error value", err)
+			}
+			if !errors.Is(buildErr, ErrIncompleteValidation) {
+				t.Errorf("Build() error = %v; want ErrIncompleteValidation", buildErr)
+			}
+		})
+	}
+}
+
+func TestValidationBuilder_BuildIsIndependent(t *testing.T) {
+	builder := NewValidation("Age").Code(2001).Value(-1)
+	first, _ := builder.Build()
+	second, _ := builder.Value(-2).Build()
+
+	if first.Value != -1 || second.Value != -2 {
+		t.Errorf("Build() results should not share state: got %v and %v", first.Value, second.Value)
+	}
+}
@@ -0,0 +1,113 @@
+package custom
+
+import (
+	"fmt"
+	"sync"
+)
+
+// CanonicalCode is a failure kind shared by every domain. Each domain owns
+// a block of 1000 numeric codes and lays its codes out as base+offset, so
+// "negative" is 1001 in example and 2001 in user and clients can branch on
+// the canonical name without knowing which package produced the error.
+type CanonicalCode int
+
+// Canonical offsets. The values mirror the codes the user and example
+// domains shipped with, so existing numeric codes did not change.
+const (
+	CodeNegative      CanonicalCode = 1
+	CodeTooLarge      CanonicalCode = 2
+	CodeRequired      CanonicalCode = 3
+	CodeTooSmall      CanonicalCode = 4
+	CodeInvalidType   CanonicalCode = 5
+	CodeNotAllowed    CanonicalCode = 6
+	CodeInvalidFormat CanonicalCode = 13
+)
+
+var canonicalNames = map[CanonicalCode]string{
+	CodeNegative:      "NEGATIVE",
+	CodeTooLarge:      "TOO_LARGE",
+	CodeRequired:      "REQUIRED",
+	CodeTooSmall:      "TOO_SMALL",
+	CodeInvalidType:   "INVALID_TYPE",
+	CodeNotAllowed:    "NOT_ALLOWED",
+	CodeInvalidFormat: "INVALID_FORMAT",
+}
+
+// String returns the machine-readable name, e.g. "TOO_LARGE".
+func (c CanonicalCode) String() string {
+	if name, ok := canonicalNames[c]; ok {
+		return name
+	}
+	return fmt.Sprintf("CANONICAL_%d", int(c))
+}
+
+// canonicalByCode maps numeric codes to their canonical kind.
+var canonicalByCode = struct {
+	sync.RWMutex
+	m map[int]CanonicalCode
+}{m: make(map[int]CanonicalCode)}
+
+// Domain is a package's block of numeric codes starting at Base.
+type Domain struct {
+	Name string
+	Base int
+}
+
+// MustDomain claims base..base+999 for name in DefaultCodeRegistry.
+func MustDomain(name string, base int) Domain {
+	MustRegisterRange(name, base, base+999)
+	return Domain{Name: name, Base: base}
+}
+
+// Code returns the numeric code for c in d, e.g. 2003 for CodeRequired in
+// a domain based at 2000.
+func (d Domain) Code(c CanonicalCode) int {
+	return d.Base + int(c)
+}
+
+// MustRegister describes d.Code(c) in DefaultCodeRegistry and records c as
+// its canonical kind.
+func (d Domain) MustRegister(c CanonicalCode, description string) {
+	MustRegister(d.Code(c), description)
+	mapCanonical(d.Code(c), c)
+}
+
+// Canonical returns the canonical kind recorded for a numeric code.
+func Canonical(code int) (CanonicalCode, bool) {
+	canonicalByCode.RLock()
+	defer canonicalByCode.RUnlock()
+	c, ok := canonicalByCode.m[code]
+	return c, ok
+}
+
+func mapCanonical(code int, c CanonicalCode) {
+	canonicalByCode.Lock()
+	defer canonicalByCode.Unlock()
+	canonicalByCode.m[code] = c
+}
+
+// CodeMapping is one row of CodeTable.
+type CodeMapping struct {
+	Code        int    `json:"code"`
+	Domain      string `json:"domain"`
+	Canonical   string `json:"canonical,omitempty"`
+	Description string `json:"description"`
+}
+
+// CodeTable lists every code in DefaultCodeRegistry in ascending order with
+// its owning domain and canonical name, for documentation pages and API
+// discovery endpoints.
+func CodeTable() []CodeMapping {
+	codes := DefaultCodeRegistry.Codes()
+	table := make([]CodeMapping, 0, len(codes))
+	for _, code := range codes {
+		row := CodeMapping{Code: code}
+		row.Domain, _ = DefaultCodeRegistry.Owner(code)
+		row.Description, _ = DefaultCodeRegistry.Describe(code)
+		if c, ok := Canonical(code); ok {
+			row.Canonical = c.String()
+		}
+		table = append(table, row)
+	}
+	return table
+}
@@ -0,0 +1,67 @@
+package custom
+
+import "testing"
+
+func TestDomain_Code(t *testing.T) {
+	d := Domain{Name: "user", Base: 2000}
+	tests := []struct {
+		canonical CanonicalCode
+		expected  int
+	}{
+		{CodeNegative, 2001},
+		{CodeTooLarge, 2002},
+		{CodeRequired, 2003},
+		{CodeInvalidFormat, 2013},
+	}
+	for _, tt := range tests {
+		if got := d.Code(tt.canonical); got != tt.expected {
+			t.Errorf("Code(%v) = %d; want %d", tt.canonical, got, tt.expected)
+		}
+	}
+}
+
+func TestCanonicalCode_String(t *testing.T) {
+	if got := CodeTooLarge.String(); got != "TOO_LARGE" {
+		t.Errorf("String() = %q; want TOO_LARGE", got)
+	}
+	if got := CanonicalCode(99).String(); got != "CANONICAL_99" {
+		t.Errorf("String() = %q; want CANONICAL_99", got)
+	}
+}
+
+func TestCanonical_RuleCodes(t *testing.T) {
+	tests := map[int]CanonicalCode{
+		CodeRuleRequired: CodeRequired,
+		CodeRuleMax:      CodeTooLarge,
+		CodeRuleEmail:    CodeInvalidFormat,
+	}
+	for code, expected := range tests {
+		if got, ok := Canonical(code); !ok || got != expected {
+			t.Errorf("Canonical(%d) = %v, %v; want %v", code, got, ok, expected)
+		}
+	}
+	if _, ok := Canonical(4999); ok {
+		t.Error("Canonical(unmapped) should report false")
+	}
+}
+
+func TestCodeTable(t *testing.T) {
+	table := CodeTable()
+	if len(table) == 0 {
+		t.Fatal("CodeTable() returned no rows")
+	}
+	for i := 1; i < len(table); i++ {
+		if table[i-1].Code >= table[i].Code {
+			t.Fatalf("CodeTable() not sorted at %d: %v", i, table)
+		}
+	}
+	for _, row := range table {
+		if row.Code == CodeRuleRequired {
+			if row.Domain != "custom" || row.Canonical != "REQUIRED" || row.Description == "" {
+				t.Errorf("row for %d = %+v; want custom/REQUIRED with a description", row.Code, row)
+			}
+			return
+		}
+	}
+	t.Errorf("CodeTable() missing code %d", CodeRuleRequired)
+}
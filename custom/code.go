@@ -0,0 +1,18 @@
+package custom
+
+import "fmt"
+
+// Code is a lightweight sentinel that matches any ValidationError with the
+// same code, so callers can write errors.Is(err, custom.Code(1002)) instead
+// of extracting the error with errors.As first.
+type Code int
+
+func (c Code) Error() string {
+	return fmt.Sprintf("validation code %d", int(c))
+}
+
+// Is makes errors.Is match a Code target against e.Code.
+func (e *ValidationError) Is(target error) bool {
+	code, ok := target.(Code)
+	return ok && int(code) == e.Code
+}
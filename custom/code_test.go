@@ -0,0 +1,41 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestValidationError_IsCode(t *testing.T) {
+	err := fmt.Errorf("signup failed: %w", &ValidationError{Field: "value", Message: "Value cannot be greater than 100", Code: 1002, Value: 150})
+
+	if !errors.Is(err, Code(1002)) {
+		t.Error("errors.Is should match the error's code")
+	}
+	if errors.Is(err, Code(1001)) {
+		t.Error("errors.Is should not match a different code")
+	}
+	if errors.Is(err, errors.New("validation code 1002")) {
+		t.Error("errors.Is should only match Code targets")
+	}
+}
+
+func TestValidationError_IsCodeInCollection(t *testing.T) {
+	err := ValidationErrors{
+		{Field: "Age", Code: 2001},
+		{Field: "Email", Code: 2003},
+	}
+
+	if !errors.Is(err, Code(2003)) {
+		t.Error("errors.Is should match a code anywhere in ValidationErrors")
+	}
+	if errors.Is(err, Code(2002)) {
+		t.Error("errors.Is should not match a code absent from ValidationErrors")
+	}
+}
+
+func TestCode_Error(t *testing.T) {
+	if got := Code(1002).Error(); got != "validation code 1002" {
+		t.Errorf("Code(1002).Error() = %q; want %q", got, "validation code 1002")
+	}
+}
@@ -0,0 +1,56 @@
+package custom
+
+import (
+	"fmt"
+	"sort"
+)
+
+// CodeRuleElement marks an element whose validator failed with an error
+// that was not itself a ValidationError.
+const CodeRuleElement = 4004
+
+// ValidateSlice runs validate on every item and reports failures under
+// index-aware paths such as "Items[3].Price", so batch payloads say exactly
+// which element failed. It returns ValidationErrors or nil.
+func ValidateSlice[T any](field string, items []T, validate func(T) error) error {
+	var errs ValidationErrors
+	for i, item := range items {
+		errs = append(errs, elementErrors(fmt.Sprintf("%s[%d]", field, i), validate(item))...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidateMap is ValidateSlice for maps, using the key in the path
+// ("Prices[apple]"). Keys are visited in sorted order of their string form
+// so reports are deterministic.
+func ValidateMap[K comparable, V any](field string, items map[K]V, validate func(K, V) error) error {
+	keys := make([]K, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+
+	var errs ValidationErrors
+	for _, key := range keys {
+		errs = append(errs, elementErrors(fmt.Sprintf("%s[%v]", field, key), validate(key, items[key]))...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func elementErrors(path string, err error) ValidationErrors {
+	if err == nil {
+		return nil
+	}
+	if ves := AllValidationErrors(err); len(ves) > 0 {
+		return ValidationErrors(ves).WithParent(path)
+	}
+	return ValidationErrors{{Field: path, Message: "element is invalid", Code: CodeRuleElement, Err: err}}
+}
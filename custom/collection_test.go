@@ -0,0 +1,97 @@
+package custom
+
+import (
+	"errors"
+	"testing"
+)
+
+type lineItem struct {
+	Name  string
+	Price int
+}
+
+func validateLineItem(item lineItem) error {
+	var errs ValidationErrors
+	if item.Name == "" {
+		errs = append(errs, &ValidationError{Field: "Name", Message: "Name is required", Code: CodeRuleRequired})
+	}
+	if ve := ValidateRange("Price", item.Price, 0, 1000, CodeRuleMin); ve != nil {
+		errs = append(errs, ve)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+func TestValidateSlice(t *testing.T) {
+	items := []lineItem{
+		{Name: "book", Price: 10},
+		{Name: "", Price: 5},
+		{Name: "pen", Price: 1},
+		{Name: "lamp", Price: -3},
+	}
+
+	err := ValidateSlice("Items", items, validateLineItem)
+
+	fields := fieldsOf(err)
+	expected := []string{"Items[1].Name", "Items[3].Price"}
+	assertFields(t, fields, expected)
+}
+
+func TestValidateSlice_AllValid(t *testing.T) {
+	if err := ValidateSlice("Items", []lineItem{{Name: "book", Price: 10}}, validateLineItem); err != nil {
+		t.Errorf("ValidateSlice(valid) returned unexpected error: %v", err)
+	}
+}
+
+func TestValidateSlice_PlainErrors(t *testing.T) {
+	errBroken := errors.New("broken element")
+	err := ValidateSlice("Tags", []string{"ok", "bad"}, func(tag string) error {
+		if tag == "bad" {
+			return errBroken
+		}
+		return nil
+	})
+
+	ves := AllValidationErrors(err)
+	if len(ves) != 1 || ves[0].Field != "Tags[1]" || ves[0].Code != CodeRuleElement {
+		t.Fatalf("ValidateSlice(plain error) = %v; want one Tags[1] element error", err)
+	}
+	if !errors.Is(err, errBroken) {
+		t.Error("element errors should wrap the validator's error")
+	}
+}
+
+func TestValidateMap(t *testing.T) {
+	prices := map[string]int{"pear": -1, "apple": 2000, "fig": 3}
+
+	err := ValidateMap("Prices", prices, func(name string, price int) error {
+		if ve := ValidateRange("", price, 0, 1000, CodeRuleMax); ve != nil {
+			return ve
+		}
+		return nil
+	})
+
+	assertFields(t, fieldsOf(err), []string{"Prices[apple]", "Prices[pear]"})
+}
+
+func fieldsOf(err error) []string {
+	var fields []string
+	for _, ve := range AllValidationErrors(err) {
+		fields = append(fields, ve.Field)
+	}
+	return fields
+}
+
+func assertFields(t *testing.T, fields, expected []string) {
+	t.Helper()
+	if len(fields) != len(expected) {
+		t.Fatalf("fields = %v; want %v", fields, expected)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("field %d = %q; want %q", i, fields[i], expected[i])
+		}
+	}
+}
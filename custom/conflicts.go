@@ -0,0 +1,33 @@
+package custom
+
+// ConflictingFields returns the fields that received two or more errors with
+// different codes, in the order each field was first seen. That usually
+// means two validators disagree about the same input.
+func ConflictingFields(errs []*ValidationError) []string {
+	firstCode := make(map[string]int)
+	var order []string
+	conflicting := make(map[string]bool)
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		code, seen := firstCode[err.Field]
+		if !seen {
+			firstCode[err.Field] = err.Code
+			order = append(order, err.Field)
+			continue
+		}
+		if code != err.Code {
+			conflicting[err.Field] = true
+		}
+	}
+
+	var fields []string
+	for _, field := range order {
+		if conflicting[field] {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,59 @@
+package custom
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConflictingFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		errs     []*ValidationError
+		expected []string
+	}{
+		{
+			name: "different codes on same field",
+			errs: []*ValidationError{
+				{Field: "Email", Message: "Email cannot be empty", Code: 2003},
+				{Field: "Email", Message: "Email format is invalid", Code: 2004},
+			},
+			expected: []string{"Email"},
+		},
+		{
+			name: "single error per field",
+			errs: []*ValidationError{
+				{Field: "Email", Message: "Email cannot be empty", Code: 2003},
+				{Field: "Age", Message: "Age cannot be negative", Code: 2001},
+			},
+			expected: nil,
+		},
+		{
+			name: "duplicate identical errors",
+			errs: []*ValidationError{
+				{Field: "Age", Message: "Age cannot be negative", Code: 2001},
+				{Field: "Age", Message: "Age cannot be negative", Code: 2001},
+			},
+			expected: nil,
+		},
+		{
+			name: "order of first appearance",
+			errs: []*ValidationError{
+				{Field: "Name", Code: 1},
+				{Field: "Age", Code: 2001},
+				{Field: "Name", Code: 2},
+				{Field: "Age", Code: 2002},
+				nil,
+			},
+			expected: []string{"Name", "Age"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := ConflictingFields(tt.errs)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("ConflictingFields() = %v; want %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package custom
+
+import (
+	"encoding/json"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestValidateRange_Constraints(t *testing.T) {
+	tests := []struct {
+		name     string
+		min, max int
+		value    int
+		expected map[string]any
+	}{
+		{"two-sided", 0, 130, 200, map[string]any{"min": 0, "max": 130}},
+		{"lower bound only", 0, math.MaxInt, -1, map[string]any{"min": 0}},
+		{"upper bound only", math.MinInt, 130, 131, map[string]any{"max": 130}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRange("Age", tt.value, tt.min, tt.max, 2001)
+			if err == nil {
+				t.Fatal("ValidateRange expected error but got none")
+			}
+			if !reflect.DeepEqual(err.Constraints, tt.expected) {
+				t.Errorf("Constraints = %v; want %v", err.Constraints, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateStruct_Constraints(t *testing.T) {
+	type form struct {
+		Name string `validate:"required,max=3"`
+		Age  int    `validate:"min=18"`
+	}
+
+	errs := AllValidationErrors(ValidateStruct(form{Name: "", Age: 12}))
+	if len(errs) != 2 {
+		t.Fatalf("ValidateStruct returned %d errors; want 2", len(errs))
+	}
+	if !reflect.DeepEqual(errs[0].Constraints, map[string]any{"required": true}) {
+		t.Errorf("required constraints = %v", errs[0].Constraints)
+	}
+	if !reflect.DeepEqual(errs[1].Constraints, map[string]any{"min": float64(18)}) {
+		t.Errorf("min constraints = %v", errs[1].Constraints)
+	}
+
+	errs = AllValidationErrors(ValidateStruct(form{Name: "Alexander", Age: 30}))
+	if len(errs) != 1 || !reflect.DeepEqual(errs[0].Constraints, map[string]any{"max": float64(3), "length": true}) {
+		t.Errorf("length constraints = %v", errs)
+	}
+}
+
+func TestConstraints_JSONAndBuilder(t *testing.T) {
+	built, _ := NewValidation("Age").Code(2002).Constraint("max", 130).Build()
+
+	data, err := json.Marshal(built)
+	if err != nil {
+		t.Fatalf("json.Marshal returned unexpected error: %v", err)
+	}
+
+	var decoded ValidationError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal returned unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(decoded.Constraints, map[string]any{"max": float64(130)}) {
+		t.Errorf("decoded Constraints = %v; want map[max:130]", decoded.Constraints)
+	}
+}
@@ -0,0 +1,33 @@
+package custom
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// ToCSV writes one row per validation error under a field,code,message,value
+// header so reports can be opened directly in a spreadsheet. The message
+// column holds ResolvedMessage, so errors built from a bare code or a
+// template export the same text Error() reports.
+func ToCSV(errs []*ValidationError, w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"field", "code", "message", "value"}); err != nil {
+		return fmt.Errorf("failed to write csv header: %w", err)
+	}
+	for _, e := range errs {
+		if e == nil {
+			continue
+		}
+		row := []string{e.Field, strconv.Itoa(e.Code), e.ResolvedMessage(), fmt.Sprint(e.DisplayValue())}
+		if err := writer.Write(row); err != nil {
+			return fmt.Errorf("failed to write csv row for field %s: %w", e.Field, err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to flush csv: %w", err)
+	}
+	return nil
+}
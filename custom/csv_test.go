@@ -0,0 +1,93 @@
+package custom
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"testing"
+)
+
+func TestToCSV(t *testing.T) {
+	errs := []*ValidationError{
+		{Field: "Age", Message: "Age cannot be negative", Code: 2001, Value: -5},
+		{Field: "Email", Message: "Email is required, please provide one", Code: 2003, Value: ""},
+		{Field: "Name", Message: `Name contains "quotes"`, Code: 3001, Value: `O"Brien`},
+	}
+
+	var buf bytes.Buffer
+	if err := ToCSV(errs, &buf); err != nil {
+		t.Fatalf("ToCSV returned unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("ToCSV output is not valid CSV: %v", err)
+	}
+
+	if len(records) != len(errs)+1 {
+		t.Fatalf("ToCSV wrote %d rows; want %d", len(records), len(errs)+1)
+	}
+
+	expected := [][]string{
+		{"field", "code", "message", "value"},
+		{"Age", "2001", "Age cannot be negative", "-5"},
+		{"Email", "2003", "Email is required, please provide one", ""},
+		{"Name", "3001", `Name contains "quotes"`, `O"Brien`},
+	}
+	for i, row := range expected {
+		for j, cell := range row {
+			if records[i][j] != cell {
+				t.Errorf("row %d column %d = %q; want %q", i, j, records[i][j], cell)
+			}
+		}
+	}
+}
+
+func TestToCSV_ResolvedMessage(t *testing.T) {
+	templated, err := (&ValidationError{Field: "Age", Code: CodeRuleMax, Constraints: map[string]any{"max": 130}}).
+		WithMessageTemplate("{{.Field}} must be at most {{.Constraints.max}}")
+	if err != nil {
+		t.Fatalf("WithMessageTemplate() error = %v", err)
+	}
+	errs := []*ValidationError{templated, {Field: "Email", Code: CodeRuleRequired}}
+
+	var buf bytes.Buffer
+	if err := ToCSV(errs, &buf); err != nil {
+		t.Fatalf("ToCSV returned unexpected error: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("ToCSV output is not valid CSV: %v", err)
+	}
+	if got := records[1][2]; got != "Age must be at most 130" {
+		t.Errorf("templated message = %q; want the rendered template", got)
+	}
+	if got, want := records[2][2], errs[1].ResolvedMessage(); got != want || got == "" {
+		t.Errorf("code-only message = %q; want the registry description %q", got, want)
+	}
+}
+
+func TestToCSV_Empty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ToCSV(nil, &buf); err != nil {
+		t.Fatalf("ToCSV(nil) returned unexpected error: %v", err)
+	}
+	if buf.String() != "field,code,message,value\n" {
+		t.Errorf("ToCSV(nil) = %q; want header only", buf.String())
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestToCSV_WriteFailure(t *testing.T) {
+	errs := []*ValidationError{{Field: "Age", Message: "Age cannot be negative", Code: 2001, Value: -1}}
+
+	err := ToCSV(errs, failingWriter{})
+	if err == nil {
+		t.Fatal("ToCSV with failing writer expected error but got none")
+	}
+}
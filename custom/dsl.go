@@ -0,0 +1,109 @@
+package custom
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Validator checks a whole input, typically a struct, and returns its
+// failures. Validators compose with All, FirstOf and When:
+//
+//	v := custom.All(
+//		custom.Field("Age", custom.Range(0, 130)),
+//		custom.Field("Email", custom.Required, custom.Email),
+//		custom.When(isAdmin, custom.Field("Role", custom.OneOf("admin"))),
+//	)
+//	err := custom.Validate(u, v)
+type Validator func(input any) ValidationErrors
+
+// Validate runs validators against input and returns ValidationErrors, or
+// nil when every validator passes.
+func Validate(input any, validators ...Validator) error {
+	if errs := All(validators...)(input); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Field applies rules to the named field of input, stopping at the first
+// rule that fails so an empty email is reported as required rather than
+// also as malformed. name may be a dotted path ("Address.City") through
+// nested structs, pointers and map[string] values. A missing field is
+// checked as nil, so Required reports it.
+func Field(name string, rules ...Rule) Validator {
+	return func(input any) ValidationErrors {
+		value := lookupField(input, name)
+		for _, rule := range rules {
+			if ve := rule(name, value); ve != nil {
+				return ValidationErrors{ve}
+			}
+		}
+		return nil
+	}
+}
+
+// All runs every validator and accumulates their failures in order.
+func All(validators ...Validator) Validator {
+	return func(input any) ValidationErrors {
+		var errs ValidationErrors
+		for _, validate := range validators {
+			errs = append(errs, validate(input)...)
+		}
+		return errs
+	}
+}
+
+// FirstOf runs validators in order and stops at the first one that fails,
+// for checks where later validators assume earlier ones passed.
+func FirstOf(validators ...Validator) Validator {
+	return func(input any) ValidationErrors {
+		for _, validate := range validators {
+			if errs := validate(input); len(errs) > 0 {
+				return errs
+			}
+		}
+		return nil
+	}
+}
+
+// When runs validator only if cond holds for input.
+func When(cond func(input any) bool, validator Validator) Validator {
+	return func(input any) ValidationErrors {
+		if !cond(input) {
+			return nil
+		}
+		return validator(input)
+	}
+}
+
+// lookupField resolves a dotted path in input, returning nil when any
+// segment is missing or passes through a nil pointer.
+func lookupField(input any, path string) any {
+	current := reflect.ValueOf(input)
+	for _, segment := range strings.Split(path, ".") {
+		for current.Kind() == reflect.Pointer || current.Kind() == reflect.Interface {
+			if current.IsNil() {
+				return nil
+			}
+			current = current.Elem()
+		}
+		switch current.Kind() {
+		case reflect.Struct:
+			current = current.FieldByName(segment)
+			if current.IsValid() && !current.CanInterface() {
+				return nil
+			}
+		case reflect.Map:
+			if current.Type().Key().Kind() != reflect.String {
+				return nil
+			}
+			current = current.MapIndex(reflect.ValueOf(segment).Convert(current.Type().Key()))
+		default:
+			return nil
+		}
+		if !current.IsValid() {
+			return nil
+		}
+	}
+	return current.Interface()
+}
@@ -0,0 +1,114 @@
+package custom
+
+import (
+	"errors"
+	"testing"
+)
+
+type dslAddress struct {
+	City string
+}
+
+type dslUser struct {
+	Age     int
+	Email   string
+	Role    string
+	Address *dslAddress
+	secret  string
+}
+
+func isAdmin(input any) bool {
+	u, ok := input.(dslUser)
+	return ok && u.Role == "admin"
+}
+
+func dslValidator() Validator {
+	return All(
+		Field("Age", Range(0, 130)),
+		Field("Email", Required, Email),
+		When(isAdmin, Field("Address.City", Required)),
+	)
+}
+
+func TestValidate_Valid(t *testing.T) {
+	u := dslUser{Age: 30, Email: "a@b.io", Role: "admin", Address: &dslAddress{City: "Oslo"}}
+	if err := Validate(u, dslValidator()); err != nil {
+		t.Errorf("Validate() = %v; want nil", err)
+	}
+}
+
+func TestValidate_Accumulates(t *testing.T) {
+	u := dslUser{Age: 131, Email: "", Role: "admin"}
+
+	err := Validate(u, dslValidator())
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Validate() = %v; want ValidationErrors", err)
+	}
+
+	expected := []struct {
+		field string
+		code  int
+	}{
+		{"Age", CodeRuleMax},
+		{"Email", CodeRuleRequired},
+		{"Address.City", CodeRuleRequired},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("Validate() returned %d errors; want %d: %v", len(errs), len(expected), errs)
+	}
+	for i, e := range expected {
+		if errs[i].Field != e.field || errs[i].Code != e.code {
+			t.Errorf("errs[%d] = %s/%d; want %s/%d", i, errs[i].Field, errs[i].Code, e.field, e.code)
+		}
+	}
+}
+
+func TestWhen_SkipsWhenFalse(t *testing.T) {
+	u := dslUser{Age: 30, Email: "a@b.io", Role: "user"}
+	if err := Validate(u, dslValidator()); err != nil {
+		t.Errorf("Validate() = %v; want the admin-only check skipped", err)
+	}
+}
+
+func TestFirstOf_ShortCircuits(t *testing.T) {
+	calls := 0
+	counting := func(input any) ValidationErrors {
+		calls++
+		return nil
+	}
+
+	errs := FirstOf(Field("Email", Required), counting)(dslUser{})
+	if len(errs) != 1 || errs[0].Code != CodeRuleRequired {
+		t.Errorf("FirstOf() = %v; want the required error", errs)
+	}
+	if calls != 0 {
+		t.Errorf("FirstOf() ran %d validators after a failure; want 0", calls)
+	}
+}
+
+func TestField_Lookup(t *testing.T) {
+	tests := []struct {
+		name  string
+		input any
+		path  string
+	}{
+		{"map input", map[string]any{"Email": ""}, "Email"},
+		{"missing field", dslUser{}, "Nickname"},
+		{"nil pointer segment", dslUser{}, "Address.City"},
+		{"unexported field", dslUser{secret: "x"}, "secret"},
+		{"non-struct input", 42, "Email"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := Field(tt.path, Required)(tt.input)
+			if len(errs) != 1 || errs[0].Field != tt.path || errs[0].Code != CodeRuleRequired {
+				t.Errorf("Field(%q) = %v; want a required error", tt.path, errs)
+			}
+		})
+	}
+
+	if errs := Field("Address.City", Required)(&dslUser{Address: &dslAddress{City: "Oslo"}}); errs != nil {
+		t.Errorf("Field(nested) = %v; want nil", errs)
+	}
+}
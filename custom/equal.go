@@ -0,0 +1,40 @@
+package custom
+
+// Equal reports whether a and b carry the same validation errors in the
+// same order, comparing Field, Code and Message and ignoring Value, Hint,
+// Constraints and causes. Wrapping and joining differences are ignored too,
+// so a bare *ValidationError equals the same error wrapped with %w. Errors
+// without validation errors fall back to comparing their messages.
+func Equal(a, b error) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	left, right := OrderedValidationErrors(a), OrderedValidationErrors(b)
+	if len(left) == 0 && len(right) == 0 {
+		return a.Error() == b.Error()
+	}
+	if len(left) != len(right) {
+		return false
+	}
+	for i := range left {
+		if !sameValidationError(left[i], right[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Comparer returns the equality Equal applies to individual entries, for
+// use with cmp.Comparer in tests that diff larger structures:
+//
+//	cmp.Diff(want, got, cmp.Comparer(custom.Comparer()))
+func Comparer() func(x, y *ValidationError) bool {
+	return sameValidationError
+}
+
+func sameValidationError(x, y *ValidationError) bool {
+	if x == nil || y == nil {
+		return x == y
+	}
+	return x.Field == y.Field && x.Code == y.Code && x.Message == y.Message
+}
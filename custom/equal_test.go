@@ -0,0 +1,51 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	age := &ValidationError{Field: "Age", Message: "Age cannot be negative", Code: 2001, Value: -1}
+	ageOtherValue := &ValidationError{Field: "Age", Message: "Age cannot be negative", Code: 2001, Value: -7, Hint: "use 0-130"}
+	email := &ValidationError{Field: "Email", Message: "Email cannot be empty", Code: 2003}
+
+	tests := []struct {
+		name     string
+		a, b     error
+		expected bool
+	}{
+		{"both nil", nil, nil, true},
+		{"one nil", age, nil, false},
+		{"ignores value and hint", age, ageOtherValue, true},
+		{"ignores wrapping", age, fmt.Errorf("request: %w", ageOtherValue), true},
+		{"slice and join", ValidationErrors{age, email}, errors.Join(ageOtherValue, email), true},
+		{"different code", age, &ValidationError{Field: "Age", Message: "Age cannot be negative", Code: 2002}, false},
+		{"different order", ValidationErrors{age, email}, ValidationErrors{email, age}, false},
+		{"different length", ValidationErrors{age, email}, age, false},
+		{"plain errors by message", errors.New("boom"), errors.New("boom"), true},
+		{"plain and validation", errors.New("boom"), age, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Equal(tt.a, tt.b); got != tt.expected {
+				t.Errorf("Equal(%v, %v) = %v; want %v", tt.a, tt.b, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestComparer(t *testing.T) {
+	eq := Comparer()
+	a := &ValidationError{Field: "Age", Message: "too old", Code: 2002, Value: 131}
+	b := &ValidationError{Field: "Age", Message: "too old", Code: 2002, Value: 200}
+
+	if !eq(a, b) {
+		t.Error("Comparer() should ignore Value")
+	}
+	if eq(a, nil) || !eq(nil, nil) {
+		t.Error("Comparer() should only match nil with nil")
+	}
+}
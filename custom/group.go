@@ -0,0 +1,31 @@
+package custom
+
+// GroupByField buckets every *ValidationError in err's tree by Field, keeping
+// the join order within each bucket. It returns nil when err carries no
+// validation errors.
+func GroupByField(err error) map[string][]*ValidationError {
+	all := AllValidationErrors(err)
+	if len(all) == 0 {
+		return nil
+	}
+	groups := make(map[string][]*ValidationError)
+	for _, ve := range all {
+		groups[ve.Field] = append(groups[ve.Field], ve)
+	}
+	return groups
+}
+
+// FieldsWithErrors lists the distinct fields in es in first-seen order, so
+// forms can render fields in a stable order alongside GroupByField.
+func (es ValidationErrors) FieldsWithErrors() []string {
+	var fields []string
+	seen := make(map[string]bool, len(es))
+	for _, ve := range es {
+		if ve == nil || seen[ve.Field] {
+			continue
+		}
+		seen[ve.Field] = true
+		fields = append(fields, ve.Field)
+	}
+	return fields
+}
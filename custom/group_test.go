@@ -0,0 +1,50 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestGroupByField(t *testing.T) {
+	ageLow := &ValidationError{Field: "Age", Code: 1}
+	email := &ValidationError{Field: "Email", Code: 2}
+	ageType := &ValidationError{Field: "Age", Code: 3}
+
+	err := errors.Join(ageLow, fmt.Errorf("contact: %w", email), errors.New("plain"), ageType)
+
+	groups := GroupByField(err)
+	if len(groups) != 2 {
+		t.Fatalf("GroupByField() returned %d groups; want 2", len(groups))
+	}
+	assertSameValidationErrors(t, groups["Age"], []*ValidationError{ageLow, ageType})
+	assertSameValidationErrors(t, groups["Email"], []*ValidationError{email})
+}
+
+func TestGroupByField_NoValidationErrors(t *testing.T) {
+	if groups := GroupByField(errors.New("plain")); groups != nil {
+		t.Errorf("GroupByField() = %v; want nil", groups)
+	}
+	if groups := GroupByField(nil); groups != nil {
+		t.Errorf("GroupByField(nil) = %v; want nil", groups)
+	}
+}
+
+func TestValidationErrors_FieldsWithErrors(t *testing.T) {
+	errs := ValidationErrors{
+		{Field: "Name"},
+		{Field: "Age"},
+		nil,
+		{Field: "Name"},
+		{Field: "Email"},
+	}
+
+	expected := []string{"Name", "Age", "Email"}
+	if fields := errs.FieldsWithErrors(); !reflect.DeepEqual(fields, expected) {
+		t.Errorf("FieldsWithErrors() = %v; want %v", fields, expected)
+	}
+	if fields := ValidationErrors(nil).FieldsWithErrors(); fields != nil {
+		t.Errorf("FieldsWithErrors() on empty = %v; want nil", fields)
+	}
+}
@@ -0,0 +1,191 @@
+package custom
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// validationErrorJSON is the wire schema. Value is tagged with its kind so
+// clients can tell the integer 1 from the string "1" after a round trip.
+type validationErrorJSON struct {
+	Field   string          `json:"field"`
+	Message string          `json:"message"`
+	Code    int             `json:"code"`
+	Value   json.RawMessage `json:"value,omitempty"`
+	// Severity is omitted for plain errors to keep the common case terse
+	Severity    Severity       `json:"severity,omitempty"`
+	Hint        string         `json:"hint,omitempty"`
+	Constraints map[string]any `json:"constraints,omitempty"`
+	// Cause carries only the message of Err; error identity cannot cross
+	// the wire.
+	Cause string `json:"cause,omitempty"`
+	// Sensitive values are never written; the flag survives so the
+	// receiving side keeps redacting.
+	Sensitive bool `json:"sensitive,omitempty"`
+}
+
+// typedValue encodes Value as {"type": ..., "data": ...}. Types are "null",
+// "bool", "int", "uint", "float", "string", "json" for composite values
+// and "text" for values that cannot be marshaled and were stringified.
+type typedValue struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data,omitempty"`
+}
+
+func (e ValidationError) MarshalJSON() ([]byte, error) {
+	sensitive := e.IsSensitive()
+	var value json.RawMessage
+	if !sensitive {
+		var err error
+		value, err = encodeValue(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode value for field %s: %w", e.Field, err)
+		}
+	}
+	var cause string
+	if e.Err != nil {
+		cause = e.Err.Error()
+	}
+	message := e.Message
+	if e.messageTemplate != nil {
+		// Receivers treat Message as literal text, so send the rendered one.
+		message = e.ResolvedMessage()
+	}
+	return json.Marshal(validationErrorJSON{
+		Field:       e.Field,
+		Message:     message,
+		Code:        e.Code,
+		Value:       value,
+		Severity:    e.Severity,
+		Hint:        e.Hint,
+		Constraints: e.Constraints,
+		Cause:       cause,
+		Sensitive:   sensitive,
+	})
+}
+
+func (e *ValidationError) UnmarshalJSON(data []byte) error {
+	var wire validationErrorJSON
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return err
+	}
+	value, err := decodeValue(wire.Value)
+	if err != nil {
+		return fmt.Errorf("failed to decode value for field %s: %w", wire.Field, err)
+	}
+	*e = ValidationError{
+		Field:       wire.Field,
+		Message:     wire.Message,
+		Code:        wire.Code,
+		Value:       value,
+		Severity:    wire.Severity,
+		Hint:        wire.Hint,
+		Constraints: wire.Constraints,
+		Sensitive:   wire.Sensitive,
+	}
+	if wire.Cause != "" {
+		e.Err = errors.New(wire.Cause)
+	}
+	return nil
+}
+
+// FromJSON decodes a validation error produced by MarshalJSON. A JSON array
+// decodes into ValidationErrors. The second result reports malformed input.
+func FromJSON(data []byte) (error, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		var errs ValidationErrors
+		if err := json.Unmarshal(trimmed, &errs); err != nil {
+			return nil, fmt.Errorf("failed to decode validation errors: %w", err)
+		}
+		return errs, nil
+	}
+	var ve ValidationError
+	if err := json.Unmarshal(trimmed, &ve); err != nil {
+		return nil, fmt.Errorf("failed to decode validation error: %w", err)
+	}
+	return &ve, nil
+}
+
+func encodeValue(value interface{}) (json.RawMessage, error) {
+	tv := typedValue{Type: "null"}
+	if value != nil {
+		switch reflect.ValueOf(value).Kind() {
+		case reflect.Bool:
+			tv.Type = "bool"
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			tv.Type = "int"
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			tv.Type = "uint"
+		case reflect.Float32, reflect.Float64:
+			tv.Type = "float"
+		case reflect.String:
+			tv.Type = "string"
+		default:
+			tv.Type = "json"
+		}
+		data, err := json.Marshal(value)
+		if err != nil {
+			// Channels, funcs and the like still deserve a readable value
+			tv.Type = "text"
+			data, err = json.Marshal(fmt.Sprint(value))
+			if err != nil {
+				return nil, err
+			}
+		}
+		tv.Data = data
+	}
+	return json.Marshal(tv)
+}
+
+// decodeValue reverses encodeValue. Integer kinds come back as int or uint
+// and floats as float64; composite values come back as generic JSON types.
+// Untagged values from other producers are accepted as plain JSON.
+func decodeValue(raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 || string(raw) == "null" {
+		return nil, nil
+	}
+
+	var tv typedValue
+	if err := json.Unmarshal(raw, &tv); err != nil || tv.Type == "" {
+		var plain interface{}
+		if err := json.Unmarshal(raw, &plain); err != nil {
+			return nil, err
+		}
+		return plain, nil
+	}
+
+	var err error
+	switch tv.Type {
+	case "null":
+		return nil, nil
+	case "bool":
+		var v bool
+		err = json.Unmarshal(tv.Data, &v)
+		return v, err
+	case "int":
+		var v int
+		err = json.Unmarshal(tv.Data, &v)
+		return v, err
+	case "uint":
+		var v uint
+		err = json.Unmarshal(tv.Data, &v)
+		return v, err
+	case "float":
+		var v float64
+		err = json.Unmarshal(tv.Data, &v)
+		return v, err
+	case "string", "text":
+		var v string
+		err = json.Unmarshal(tv.Data, &v)
+		return v, err
+	case "json":
+		var v interface{}
+		err = json.Unmarshal(tv.Data, &v)
+		return v, err
+	}
+	return nil, fmt.Errorf("unknown value type %q", tv.Type)
+}
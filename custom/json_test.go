@@ -0,0 +1,122 @@
+package custom
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestValidationError_JSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+	}{
+		{"int value", -5},
+		{"uint value", uint(7)},
+		{"float value", -10.5},
+		{"string value", "1"},
+		{"bool value", true},
+		{"nil value", nil},
+		{"composite value", map[string]interface{}{"min": float64(0), "max": float64(130)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := &ValidationError{Field: "age", Message: "Age must be positive", Code: 1002, Value: tt.value}
+
+			data, err := json.Marshal(original)
+			if err != nil {
+				t.Fatalf("json.Marshal returned unexpected error: %v", err)
+			}
+
+			var decoded ValidationError
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("json.Unmarshal returned unexpected error: %v", err)
+			}
+
+			if decoded.Field != original.Field || decoded.Message != original.Message || decoded.Code != original.Code {
+				t.Errorf("round trip = %+v; want %+v", decoded, *original)
+			}
+			if !reflect.DeepEqual(decoded.Value, tt.value) {
+				t.Errorf("round trip value = %#v (%T); want %#v (%T)", decoded.Value, decoded.Value, tt.value, tt.value)
+			}
+		})
+	}
+}
+
+func TestValidationError_MarshalJSONSchema(t *testing.T) {
+	data, err := json.Marshal(ValidationError{Field: "age", Message: "Age must be positive", Code: 1002, Value: -5})
+	if err != nil {
+		t.Fatalf("json.Marshal returned unexpected error: %v", err)
+	}
+
+	expected := `{"field":"age","message":"Age must be positive","code":1002,"value":{"type":"int","data":-5}}`
+	if string(data) != expected {
+		t.Errorf("json.Marshal = %s; want %s", data, expected)
+	}
+}
+
+func TestValidationError_UnmarshalPlainValue(t *testing.T) {
+	var decoded ValidationError
+	if err := json.Unmarshal([]byte(`{"field":"age","code":2001,"value":-1}`), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal returned unexpected error: %v", err)
+	}
+	if decoded.Value != float64(-1) {
+		t.Errorf("untagged value = %#v; want float64(-1)", decoded.Value)
+	}
+}
+
+func TestFromJSON(t *testing.T) {
+	err, parseErr := FromJSON([]byte(`{"field":"email","message":"Invalid email format","code":2001,"value":{"type":"string","data":"invalid-email"}}`))
+	if parseErr != nil {
+		t.Fatalf("FromJSON returned unexpected parse error: %v", parseErr)
+	}
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("FromJSON = %T; want *ValidationError", err)
+	}
+	expected := "Validation error on field 'email': Invalid email format (code: 2001, value: invalid-email)"
+	if err.Error() != expected {
+		t.Errorf("FromJSON().Error() = %v; want %v", err.Error(), expected)
+	}
+}
+
+func TestFromJSON_Array(t *testing.T) {
+	original := ValidationErrors{
+		{Field: "Age", Message: "Age cannot be less than 0", Code: 2001, Value: -1},
+		{Field: "Email", Message: "Email cannot be empty", Code: 2003, Value: ""},
+	}
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("json.Marshal returned unexpected error: %v", err)
+	}
+
+	decoded, parseErr := FromJSON(data)
+	if parseErr != nil {
+		t.Fatalf("FromJSON returned unexpected parse error: %v", parseErr)
+	}
+
+	var errs ValidationErrors
+	if !errors.As(decoded, &errs) || len(errs) != 2 {
+		t.Fatalf("FromJSON(array) = %v; want 2 ValidationErrors", decoded)
+	}
+	if errs[1].Field != "Email" || errs[1].Value != "" {
+		t.Errorf("FromJSON(array)[1] = %+v; want Email entry", *errs[1])
+	}
+}
+
+func TestFromJSON_Malformed(t *testing.T) {
+	inputs := []string{`not json`, `[{"field":1}]`, `{"field":"age","value":{"type":"mystery","data":1}}`}
+
+	for _, input := range inputs {
+		err, parseErr := FromJSON([]byte(input))
+		if parseErr == nil {
+			t.Errorf("FromJSON(%s) expected parse error but got none", input)
+		}
+		if err != nil {
+			t.Errorf("FromJSON(%s) = %v; want nil error value on failure", input, err)
+		}
+	}
+}
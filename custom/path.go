@@ -0,0 +1,35 @@
+package custom
+
+import "strings"
+
+// JoinPath appends child to a parent field path: JoinPath("Address", "City")
+// is "Address.City" and JoinPath("Items", "[3]") is "Items[3]".
+func JoinPath(parent, child string) string {
+	switch {
+	case parent == "":
+		return child
+	case child == "":
+		return parent
+	case strings.HasPrefix(child, "["):
+		return parent + child
+	}
+	return parent + "." + child
+}
+
+// WithParent returns a copy of e whose Field is nested under prefix, so a
+// validator written for a standalone struct can be reused for an embedded
+// one without knowing where it sits in the request.
+func (e *ValidationError) WithParent(prefix string) *ValidationError {
+	nested := e.Clone()
+	nested.Field = JoinPath(prefix, e.Field)
+	return nested
+}
+
+// WithParent nests every entry under prefix, leaving e unchanged.
+func (e ValidationErrors) WithParent(prefix string) ValidationErrors {
+	nested := make(ValidationErrors, len(e))
+	for i, ve := range e {
+		nested[i] = ve.WithParent(prefix)
+	}
+	return nested
+}
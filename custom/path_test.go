@@ -0,0 +1,64 @@
+package custom
+
+import "testing"
+
+func TestJoinPath(t *testing.T) {
+	tests := []struct {
+		parent, child, expected string
+	}{
+		{"Address", "City", "Address.City"},
+		{"Items", "[3]", "Items[3]"},
+		{"Items[3]", "Price", "Items[3].Price"},
+		{"", "City", "City"},
+		{"Address", "", "Address"},
+	}
+
+	for _, tt := range tests {
+		if got := JoinPath(tt.parent, tt.child); got != tt.expected {
+			t.Errorf("JoinPath(%q, %q) = %q; want %q", tt.parent, tt.child, got, tt.expected)
+		}
+	}
+}
+
+func TestValidationError_WithParent(t *testing.T) {
+	original := &ValidationError{Field: "City", Message: "City is required", Code: 4001, Value: ""}
+
+	nested := original.WithParent("Address")
+
+	if nested.Field != "Address.City" {
+		t.Errorf("WithParent().Field = %q; want %q", nested.Field, "Address.City")
+	}
+	if original.Field != "City" {
+		t.Errorf("WithParent should not modify the original, got Field %q", original.Field)
+	}
+	if nested.Code != original.Code || nested.Message != original.Message {
+		t.Errorf("WithParent should keep code and message, got %+v", *nested)
+	}
+}
+
+func TestValidationError_WithParentCopiesConstraints(t *testing.T) {
+	original := &ValidationError{Field: "Age", Code: CodeRuleMax, Constraints: map[string]any{"max": 130}}
+
+	nested := original.WithParent("Owner")
+	nested.Constraints["max"] = 120
+
+	if original.Constraints["max"] != 130 {
+		t.Errorf("original Constraints[max] = %v; want 130", original.Constraints["max"])
+	}
+}
+
+func TestValidationErrors_WithParent(t *testing.T) {
+	errs := ValidationErrors{
+		{Field: "Price", Code: CodeRuleMin},
+		{Field: "Name", Code: CodeRuleRequired},
+	}
+
+	nested := errs.WithParent("Items[3]")
+
+	if nested[0].Field != "Items[3].Price" || nested[1].Field != "Items[3].Name" {
+		t.Errorf("WithParent() fields = [%s %s]; want [Items[3].Price Items[3].Name]", nested[0].Field, nested[1].Field)
+	}
+	if errs[0].Field != "Price" {
+		t.Error("WithParent should not modify the original entries")
+	}
+}
@@ -0,0 +1,57 @@
+package custom
+
+import (
+	"fmt"
+	"math"
+)
+
+// ValidateRange checks min <= value <= max and returns nil when it holds.
+// Otherwise the ValidationError message names the bound that was violated.
+// A single code is used for both directions; use ValidateBounds when "too
+// small" and "too large" need distinct codes. Pass math.MinInt or
+// math.MaxInt for an open side.
+func ValidateRange(field string, value, min, max, code int) *ValidationError {
+	return ValidateBounds(field, value, min, max, code, code)
+}
+
+// ValidateBounds reports value outside min..max using belowCode or
+// aboveCode, with the same bound-citing messages as ValidateRange. A
+// message template registered for the failing code replaces the default
+// message, so domains can word their own codes. It takes a typed int
+// rather than a Rule's any so hot paths stay allocation-free on success.
+func ValidateBounds(field string, value, min, max, belowCode, aboveCode int) *ValidationError {
+	var message string
+	var code int
+	switch {
+	case value < min:
+		message, code = fmt.Sprintf("%s cannot be less than %d", field, min), belowCode
+	case value > max:
+		message, code = fmt.Sprintf("%s cannot be greater than %d", field, max), aboveCode
+	default:
+		return nil
+	}
+	ve := &ValidationError{
+		Field:       field,
+		Code:        code,
+		Value:       value,
+		Constraints: rangeConstraints(min, max),
+	}
+	if registered, ok := ve.registeredMessage(); ok {
+		message = registered
+	}
+	ve.Message = message
+	return ve
+}
+
+// rangeConstraints omits math.MinInt/math.MaxInt bounds, which mark the
+// open side of a one-sided check rather than a real limit.
+func rangeConstraints(min, max int) map[string]any {
+	constraints := make(map[string]any, 2)
+	if min != math.MinInt {
+		constraints["min"] = min
+	}
+	if max != math.MaxInt {
+		constraints["max"] = max
+	}
+	return constraints
+}
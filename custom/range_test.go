@@ -0,0 +1,65 @@
+package custom
+
+import "testing"
+
+func TestValidateRange_InRange(t *testing.T) {
+	for _, value := range []int{0, 65, 130} {
+		if err := ValidateRange("Age", value, 0, 130, 2001); err != nil {
+			t.Errorf("ValidateRange(%d) returned unexpected error: %v", value, err)
+		}
+	}
+}
+
+func TestValidateRange_OutOfRange(t *testing.T) {
+	tests := []struct {
+		name            string
+		value           int
+		expectedMessage string
+	}{
+		{"below minimum", -1, "Age cannot be less than 0"},
+		{"above maximum", 131, "Age cannot be greater than 130"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateRange("Age", tt.value, 0, 130, 2001)
+			if err == nil {
+				t.Fatalf("ValidateRange(%d) expected error but got none", tt.value)
+			}
+			if err.Field != "Age" {
+				t.Errorf("Expected field 'Age', got '%s'", err.Field)
+			}
+			if err.Code != 2001 {
+				t.Errorf("Expected code 2001, got %d", err.Code)
+			}
+			if err.Value != tt.value {
+				t.Errorf("Expected value %d, got %v", tt.value, err.Value)
+			}
+			if err.Message != tt.expectedMessage {
+				t.Errorf("Expected message '%s', got '%s'", tt.expectedMessage, err.Message)
+			}
+		})
+	}
+}
+
+func TestValidateRange_NonZeroMinimum(t *testing.T) {
+	err := ValidateRange("Quantity", 2, 5, 10, 3001)
+	if err == nil || err.Message != "Quantity cannot be less than 5" {
+		t.Errorf("ValidateRange(2, 5..10) = %v; want message 'Quantity cannot be less than 5'", err)
+	}
+}
+
+func TestValidateBounds_RegisteredTemplate(t *testing.T) {
+	const below, above = 4993, 4994
+	if err := RegisterMessageTemplate(below, "{{.Field}} cannot be negative"); err != nil {
+		t.Fatalf("RegisterMessageTemplate() error = %v", err)
+	}
+	defer unregisterMessageTemplate(below)
+
+	if err := ValidateBounds("Age", -1, 0, 130, below, above); err == nil || err.Message != "Age cannot be negative" {
+		t.Errorf("ValidateBounds(-1) = %v; want the registered message", err)
+	}
+	if err := ValidateBounds("Age", 131, 0, 130, below, above); err == nil || err.Message != "Age cannot be greater than 130" {
+		t.Errorf("ValidateBounds(131) = %v; want the default message", err)
+	}
+}
@@ -0,0 +1,50 @@
+package custom
+
+import (
+	"strings"
+	"unicode"
+)
+
+// RedactedValue replaces sensitive values in Error(), templates, CSV and
+// JSON output.
+const RedactedValue = "[REDACTED]"
+
+// SensitiveFields names fields whose values are always redacted, matched
+// case-insensitively against the last segment of the field path, so
+// "Users[2].Password" is covered by "password".
+var SensitiveFields = []string{"password", "token", "secret", "apikey", "api_key"}
+
+// IsSensitive reports whether e's Value must not be printed, either because
+// e.Sensitive is set or because its field is listed in SensitiveFields.
+func (e *ValidationError) IsSensitive() bool {
+	if e.Sensitive {
+		return true
+	}
+	name := strings.ToLower(lastPathSegment(e.Field))
+	for _, sensitive := range SensitiveFields {
+		if name == strings.ToLower(sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// DisplayValue returns Value, or RedactedValue when e is sensitive. Anything
+// rendering an error for people or logs should use it instead of Value.
+func (e *ValidationError) DisplayValue() interface{} {
+	if e.IsSensitive() {
+		return RedactedValue
+	}
+	return e.Value
+}
+
+// lastPathSegment strips parents and indexes from a JoinPath-style path:
+// "Users[2].Password" becomes "Password".
+func lastPathSegment(path string) string {
+	if i := strings.LastIndex(path, "."); i >= 0 {
+		path = path[i+1:]
+	}
+	return strings.TrimRightFunc(path, func(r rune) bool {
+		return r == ']' || r == '[' || unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,78 @@
+package custom
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestValidationError_IsSensitive(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *ValidationError
+		expected bool
+	}{
+		{"flagged", &ValidationError{Field: "PIN", Sensitive: true}, true},
+		{"listed field", &ValidationError{Field: "password"}, true},
+		{"listed field any case", &ValidationError{Field: "Password"}, true},
+		{"nested listed field", &ValidationError{Field: "Users[2].Token"}, true},
+		{"indexed listed field", &ValidationError{Field: "Secret[0]"}, true},
+		{"ordinary field", &ValidationError{Field: "Email"}, false},
+		{"field containing listed name", &ValidationError{Field: "PasswordHint"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.IsSensitive(); got != tt.expected {
+				t.Errorf("IsSensitive() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidationError_RedactsSensitiveValue(t *testing.T) {
+	err := &ValidationError{Field: "password", Message: "password is too short", Code: 2010, Value: "hunter2"}
+
+	if msg := err.Error(); strings.Contains(msg, "hunter2") || !strings.Contains(msg, RedactedValue) {
+		t.Errorf("Error() = %q; want the value redacted", msg)
+	}
+
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatalf("json.Marshal() error = %v", jsonErr)
+	}
+	if strings.Contains(string(data), "hunter2") {
+		t.Errorf("MarshalJSON() = %s; want the value omitted", data)
+	}
+	var decoded ValidationError
+	if jsonErr := json.Unmarshal(data, &decoded); jsonErr != nil {
+		t.Fatalf("json.Unmarshal() error = %v", jsonErr)
+	}
+	if !decoded.Sensitive || decoded.Value != nil {
+		t.Errorf("decoded = %+v; want Sensitive set and no Value", decoded)
+	}
+
+	var buf bytes.Buffer
+	if csvErr := ToCSV([]*ValidationError{err}, &buf); csvErr != nil {
+		t.Fatalf("ToCSV() error = %v", csvErr)
+	}
+	if strings.Contains(buf.String(), "hunter2") {
+		t.Errorf("ToCSV() = %q; want the value redacted", buf.String())
+	}
+
+	templated, tmplErr := (&ValidationError{Field: "Token", Value: "abc123"}).WithMessageTemplate("{{.Field}} {{.Value}} expired")
+	if tmplErr != nil {
+		t.Fatalf("WithMessageTemplate() error = %v", tmplErr)
+	}
+	if got := templated.ResolvedMessage(); got != "Token "+RedactedValue+" expired" {
+		t.Errorf("ResolvedMessage() = %q; want the value redacted", got)
+	}
+}
+
+func TestValidationError_DisplayValueNotSensitive(t *testing.T) {
+	err := &ValidationError{Field: "Age", Value: 42}
+	if got := err.DisplayValue(); got != 42 {
+		t.Errorf("DisplayValue() = %v; want 42", got)
+	}
+}
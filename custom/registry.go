@@ -0,0 +1,146 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrCodeCollision reports a code or range that is already claimed.
+	ErrCodeCollision = errors.New("validation code collision")
+	// ErrCodeOutOfRange reports a code outside every registered range.
+	ErrCodeOutOfRange = errors.New("validation code outside registered ranges")
+)
+
+// CodeRegistry tracks which package owns which block of validation codes so
+// two packages cannot silently hand out the same number. Packages claim a
+// range and describe their codes from init.
+type CodeRegistry struct {
+	mu     sync.RWMutex
+	ranges []codeRange
+	codes  map[int]string
+}
+
+type codeRange struct {
+	owner    string
+	from, to int
+}
+
+func NewCodeRegistry() *CodeRegistry {
+	return &CodeRegistry{codes: make(map[int]string)}
+}
+
+// RegisterRange claims codes from..to (inclusive) for owner.
+func (r *CodeRegistry) RegisterRange(owner string, from, to int) error {
+	if from > to {
+		return fmt.Errorf("invalid range %d-%d for %s", from, to, owner)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, existing := range r.ranges {
+		if from <= existing.to && existing.from <= to {
+			return fmt.Errorf("range %d-%d for %s overlaps %d-%d owned by %s: %w",
+				from, to, owner, existing.from, existing.to, existing.owner, ErrCodeCollision)
+		}
+	}
+	r.ranges = append(r.ranges, codeRange{owner: owner, from: from, to: to})
+	return nil
+}
+
+// Register describes a single code, which must fall inside a claimed range.
+func (r *CodeRegistry) Register(code int, description string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.codes[code]; ok {
+		return fmt.Errorf("code %d already registered as %q: %w", code, existing, ErrCodeCollision)
+	}
+	if _, ok := r.ownerLocked(code); !ok {
+		return fmt.Errorf("code %d: %w", code, ErrCodeOutOfRange)
+	}
+	r.codes[code] = description
+	return nil
+}
+
+// Describe returns the registered description for code.
+func (r *CodeRegistry) Describe(code int) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	description, ok := r.codes[code]
+	return description, ok
+}
+
+// Owner returns the name that claimed the range containing code.
+func (r *CodeRegistry) Owner(code int) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.ownerLocked(code)
+}
+
+// Codes returns every registered code in ascending order.
+func (r *CodeRegistry) Codes() []int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	codes := make([]int, 0, len(r.codes))
+	for code := range r.codes {
+		codes = append(codes, code)
+	}
+	sort.Ints(codes)
+	return codes
+}
+
+func (r *CodeRegistry) ownerLocked(code int) (string, bool) {
+	for _, cr := range r.ranges {
+		if code >= cr.from && code <= cr.to {
+			return cr.owner, true
+		}
+	}
+	return "", false
+}
+
+// DefaultCodeRegistry is the registry packages in this module use. Error()
+// falls back to its descriptions for errors built without a message.
+var DefaultCodeRegistry = NewCodeRegistry()
+
+// MustRegisterRange claims a range in DefaultCodeRegistry and panics on a
+// collision. It is meant for init functions, where a collision is a
+// programming error that should stop the binary from starting.
+func MustRegisterRange(owner string, from, to int) {
+	if err := DefaultCodeRegistry.RegisterRange(owner, from, to); err != nil {
+		panic(err)
+	}
+}
+
+// MustRegister describes a code in DefaultCodeRegistry, panicking on error.
+func MustRegister(code int, description string) {
+	if err := DefaultCodeRegistry.Register(code, description); err != nil {
+		panic(err)
+	}
+}
+
+// Describe looks code up in DefaultCodeRegistry.
+func Describe(code int) (string, bool) {
+	return DefaultCodeRegistry.Describe(code)
+}
+
+func init() {
+	MustRegisterRange("custom", 4000, 4999)
+	MustRegister(CodeRuleRequired, "required field is missing")
+	MustRegister(CodeRuleMin, "value is below the minimum")
+	MustRegister(CodeRuleMax, "value is above the maximum")
+	MustRegister(CodeRuleElement, "element failed validation")
+	MustRegister(CodeRuleEmail, "email format is invalid")
+	MustRegister(CodeRulePattern, "value does not match the required format")
+	MustRegister(CodeRuleOneOf, "value is not an allowed option")
+	MustRegister(CodeRuleType, "value has the wrong type")
+
+	// The rule codes predate the canonical layout, so map them explicitly
+	mapCanonical(CodeRuleRequired, CodeRequired)
+	mapCanonical(CodeRuleMin, CodeTooSmall)
+	mapCanonical(CodeRuleMax, CodeTooLarge)
+	mapCanonical(CodeRuleEmail, CodeInvalidFormat)
+	mapCanonical(CodeRulePattern, CodeInvalidFormat)
+	mapCanonical(CodeRuleOneOf, CodeNotAllowed)
+	mapCanonical(CodeRuleType, CodeInvalidType)
+}
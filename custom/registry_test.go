@@ -0,0 +1,92 @@
+package custom
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCodeRegistry_RegisterAndDescribe(t *testing.T) {
+	registry := NewCodeRegistry()
+	if err := registry.RegisterRange("billing", 5000, 5099); err != nil {
+		t.Fatalf("RegisterRange returned unexpected error: %v", err)
+	}
+	if err := registry.Register(5001, "card declined"); err != nil {
+		t.Fatalf("Register returned unexpected error: %v", err)
+	}
+
+	description, ok := registry.Describe(5001)
+	if !ok || description != "card declined" {
+		t.Errorf("Describe(5001) = (%q, %v); want (\"card declined\", true)", description, ok)
+	}
+	if owner, ok := registry.Owner(5050); !ok || owner != "billing" {
+		t.Errorf("Owner(5050) = (%q, %v); want (\"billing\", true)", owner, ok)
+	}
+	if _, ok := registry.Describe(5002); ok {
+		t.Error("Describe(5002) should report an unregistered code")
+	}
+}
+
+func TestCodeRegistry_Collisions(t *testing.T) {
+	registry := NewCodeRegistry()
+	if err := registry.RegisterRange("billing", 5000, 5099); err != nil {
+		t.Fatalf("RegisterRange returned unexpected error: %v", err)
+	}
+	if err := registry.Register(5001, "card declined"); err != nil {
+		t.Fatalf("Register returned unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		register func() error
+		expected error
+	}{
+		{"overlapping range", func() error { return registry.RegisterRange("shipping", 5050, 5150) }, ErrCodeCollision},
+		{"duplicate code", func() error { return registry.Register(5001, "insufficient funds") }, ErrCodeCollision},
+		{"code outside ranges", func() error { return registry.Register(6001, "unknown") }, ErrCodeOutOfRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.register(); !errors.Is(err, tt.expected) {
+				t.Errorf("got %v; want %v", err, tt.expected)
+			}
+		})
+	}
+
+	if err := registry.RegisterRange("shipping", 5100, 5199); err != nil {
+		t.Errorf("adjacent range should be accepted, got %v", err)
+	}
+	if err := registry.RegisterRange("broken", 10, 1); err == nil {
+		t.Error("inverted range expected error but got none")
+	}
+}
+
+func TestCodeRegistry_Codes(t *testing.T) {
+	registry := NewCodeRegistry()
+	_ = registry.RegisterRange("billing", 5000, 5099)
+	_ = registry.Register(5003, "c")
+	_ = registry.Register(5001, "a")
+
+	if codes := registry.Codes(); !reflect.DeepEqual(codes, []int{5001, 5003}) {
+		t.Errorf("Codes() = %v; want [5001 5003]", codes)
+	}
+}
+
+func TestMustRegisterRange_PanicsOnCollision(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustRegisterRange should panic on an overlapping range")
+		}
+	}()
+	MustRegisterRange("duplicate custom", 4000, 4000)
+}
+
+func TestValidationError_ErrorUsesRegistryDescription(t *testing.T) {
+	err := &ValidationError{Field: "Email", Code: CodeRuleRequired, Value: ""}
+
+	expected := "Validation error on field 'Email': required field is missing (code: 4001, value: )"
+	if err.Error() != expected {
+		t.Errorf("Error() = %q; want %q", err.Error(), expected)
+	}
+}
@@ -0,0 +1,58 @@
+package custom
+
+// Report accumulates validation results across a pipeline, keeping
+// blocking errors apart from warnings so the caller can decide at the end
+// whether to reject the request or proceed and surface the warnings.
+// The zero value is an empty report ready to use.
+type Report struct {
+	Errors   ValidationErrors
+	Warnings ValidationErrors
+}
+
+// Add files each non-nil entry under Warnings or Errors by its Severity.
+func (r *Report) Add(errs ...*ValidationError) {
+	for _, ve := range errs {
+		switch {
+		case ve == nil:
+		case ve.Severity == SeverityWarning:
+			r.Warnings = append(r.Warnings, ve)
+		default:
+			r.Errors = append(r.Errors, ve)
+		}
+	}
+}
+
+// AddErr adds every *ValidationError found in err's tree. Errors that carry
+// no validation errors are not recorded; callers should handle them
+// separately.
+func (r *Report) AddErr(err error) {
+	r.Add(AllValidationErrors(err)...)
+}
+
+// Merge appends other's entries to r, keeping their order.
+func (r *Report) Merge(other *Report) {
+	if other == nil {
+		return
+	}
+	r.Errors = append(r.Errors, other.Errors...)
+	r.Warnings = append(r.Warnings, other.Warnings...)
+}
+
+// HasErrors reports whether any blocking error was recorded.
+func (r *Report) HasErrors() bool {
+	return len(r.Errors) > 0
+}
+
+// HasWarnings reports whether any warning was recorded.
+func (r *Report) HasWarnings() bool {
+	return len(r.Warnings) > 0
+}
+
+// Err returns the blocking errors as an error, or nil when there are none.
+// Warnings never make Err non-nil.
+func (r *Report) Err() error {
+	if !r.HasErrors() {
+		return nil
+	}
+	return r.Errors
+}
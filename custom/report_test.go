@@ -0,0 +1,68 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestReport_Add(t *testing.T) {
+	blocking := &ValidationError{Field: "Age", Code: 2001}
+	fatal := &ValidationError{Field: "Id", Code: 9, Severity: SeverityFatal}
+	warning := &ValidationError{Field: "Nickname", Code: 7, Severity: SeverityWarning}
+
+	var r Report
+	r.Add(blocking, nil, warning, fatal)
+
+	assertSameValidationErrors(t, r.Errors, []*ValidationError{blocking, fatal})
+	assertSameValidationErrors(t, r.Warnings, []*ValidationError{warning})
+	if !r.HasErrors() || !r.HasWarnings() {
+		t.Errorf("HasErrors() = %v, HasWarnings() = %v; want both true", r.HasErrors(), r.HasWarnings())
+	}
+}
+
+func TestReport_AddErr(t *testing.T) {
+	blocking := &ValidationError{Field: "Email", Code: 2003}
+	warning := &ValidationError{Field: "Age", Code: 2002, Severity: SeverityWarning}
+
+	var r Report
+	r.AddErr(fmt.Errorf("signup: %w", errors.Join(blocking, errors.New("plain"), warning)))
+	r.AddErr(nil)
+
+	assertSameValidationErrors(t, r.Errors, []*ValidationError{blocking})
+	assertSameValidationErrors(t, r.Warnings, []*ValidationError{warning})
+}
+
+func TestReport_Merge(t *testing.T) {
+	first := &ValidationError{Field: "A", Code: 1}
+	second := &ValidationError{Field: "B", Code: 2}
+	warning := &ValidationError{Field: "C", Code: 3, Severity: SeverityWarning}
+
+	var r, other Report
+	r.Add(first)
+	other.Add(second, warning)
+	r.Merge(&other)
+	r.Merge(nil)
+
+	assertSameValidationErrors(t, r.Errors, []*ValidationError{first, second})
+	assertSameValidationErrors(t, r.Warnings, []*ValidationError{warning})
+}
+
+func TestReport_Err(t *testing.T) {
+	var r Report
+	if err := r.Err(); err != nil {
+		t.Errorf("empty report Err() = %v; want nil", err)
+	}
+
+	r.Add(&ValidationError{Field: "Age", Code: 2002, Severity: SeverityWarning})
+	if err := r.Err(); err != nil {
+		t.Errorf("warnings-only report Err() = %v; want nil", err)
+	}
+
+	blocking := &ValidationError{Field: "Email", Code: 2003}
+	r.Add(blocking)
+	var ve *ValidationError
+	if err := r.Err(); !errors.As(err, &ve) || ve != blocking {
+		t.Errorf("Err() = %v; want it to carry the blocking error", err)
+	}
+}
@@ -0,0 +1,103 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// RuleFactory builds a Rule from the parameter written after "=" in a
+// validate tag ("" when there is none). It returns an error for malformed
+// parameters.
+type RuleFactory func(param string) (Rule, error)
+
+// ErrDuplicateRule is returned when registering a rule name that is taken.
+var ErrDuplicateRule = errors.New("rule already registered")
+
+// builtinTagRules are handled by ValidateStruct itself and cannot be
+// overridden.
+var builtinTagRules = map[string]bool{"required": true, "min": true, "max": true}
+
+var namedRules = struct {
+	sync.RWMutex
+	byName map[string]RuleFactory
+}{byName: make(map[string]RuleFactory)}
+
+func init() {
+	MustRegisterRule("email", Email)
+	MustRegisterRuleFactory("oneof", func(param string) (Rule, error) {
+		options := strings.Fields(param)
+		if len(options) == 0 {
+			return nil, fmt.Errorf("oneof needs at least one option: %w", ErrInvalidRule)
+		}
+		values := make([]any, len(options))
+		for i, option := range options {
+			values[i] = option
+		}
+		return OneOf(values...), nil
+	})
+}
+
+// RegisterRule makes rule available under name, both as a validate tag
+// (`validate:"phone"`) and through LookupRule.
+func RegisterRule(name string, rule Rule) error {
+	return RegisterRuleFactory(name, func(param string) (Rule, error) {
+		if param != "" {
+			return nil, fmt.Errorf("rule %q takes no parameter: %w", name, ErrInvalidRule)
+		}
+		return rule, nil
+	})
+}
+
+// RegisterRuleFactory registers a parameterized rule, used as
+// `validate:"prefix=+44"` or LookupRule("prefix", "+44").
+func RegisterRuleFactory(name string, factory RuleFactory) error {
+	if name == "" || strings.ContainsAny(name, "=, ") {
+		return fmt.Errorf("rule name %q: %w", name, ErrInvalidRule)
+	}
+	if builtinTagRules[name] {
+		return fmt.Errorf("rule %q: %w", name, ErrDuplicateRule)
+	}
+	namedRules.Lock()
+	defer namedRules.Unlock()
+	if _, exists := namedRules.byName[name]; exists {
+		return fmt.Errorf("rule %q: %w", name, ErrDuplicateRule)
+	}
+	namedRules.byName[name] = factory
+	return nil
+}
+
+// MustRegisterRule is RegisterRule for package initialization.
+func MustRegisterRule(name string, rule Rule) {
+	if err := RegisterRule(name, rule); err != nil {
+		panic(err)
+	}
+}
+
+// MustRegisterRuleFactory is RegisterRuleFactory for package initialization.
+func MustRegisterRuleFactory(name string, factory RuleFactory) {
+	if err := RegisterRuleFactory(name, factory); err != nil {
+		panic(err)
+	}
+}
+
+// LookupRule builds the registered rule name with param, for use alongside
+// the functional validators. Unknown names and bad parameters wrap
+// ErrInvalidRule.
+func LookupRule(name, param string) (Rule, error) {
+	namedRules.RLock()
+	factory, ok := namedRules.byName[name]
+	namedRules.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown rule %q: %w", name, ErrInvalidRule)
+	}
+	rule, err := factory(param)
+	if err != nil {
+		if !errors.Is(err, ErrInvalidRule) {
+			err = fmt.Errorf("%w: %w", ErrInvalidRule, err)
+		}
+		return nil, fmt.Errorf("rule %q: %w", name, err)
+	}
+	return rule, nil
+}
@@ -0,0 +1,101 @@
+package custom
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const codePhone = 4901
+
+func phoneRule(field string, value any) *ValidationError {
+	s, ok := value.(string)
+	if !ok || !strings.HasPrefix(s, "+") {
+		return &ValidationError{Field: field, Message: field + " must be an international number", Code: codePhone, Value: value}
+	}
+	return nil
+}
+
+func init() {
+	MustRegisterRule("test_phone", phoneRule)
+	MustRegisterRuleFactory("test_prefix", func(param string) (Rule, error) {
+		if param == "" {
+			return nil, errors.New("prefix is required")
+		}
+		return func(field string, value any) *ValidationError {
+			if s, _ := value.(string); !strings.HasPrefix(s, param) {
+				return &ValidationError{Field: field, Code: codePhone, Value: value, Constraints: map[string]any{"prefix": param}}
+			}
+			return nil
+		}, nil
+	})
+}
+
+func TestValidateStruct_RegisteredRules(t *testing.T) {
+	type contact struct {
+		Phone  string `validate:"required,test_phone"`
+		Mobile string `validate:"test_prefix=+44"`
+		Email  string `validate:"email"`
+		Role   string `validate:"oneof=admin user"`
+	}
+
+	if err := ValidateStruct(contact{Phone: "+15550100", Mobile: "+447700900", Email: "a@b.io", Role: "user"}); err != nil {
+		t.Fatalf("ValidateStruct(valid) = %v; want nil", err)
+	}
+
+	err := ValidateStruct(contact{Phone: "5550100", Mobile: "+15550100", Email: "nope", Role: "root"})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("ValidateStruct() = %v; want ValidationErrors", err)
+	}
+	codes := map[string]int{}
+	for _, ve := range errs {
+		codes[ve.Field] = ve.Code
+	}
+	expected := map[string]int{"Phone": codePhone, "Mobile": codePhone, "Email": CodeRuleEmail, "Role": CodeRuleOneOf}
+	for field, code := range expected {
+		if codes[field] != code {
+			t.Errorf("code for %s = %d; want %d (all: %v)", field, codes[field], code, codes)
+		}
+	}
+}
+
+func TestValidateStruct_RegisteredRuleBadParam(t *testing.T) {
+	type bad struct {
+		Mobile string `validate:"test_prefix"`
+	}
+	type unexpectedParam struct {
+		Phone string `validate:"test_phone=1"`
+	}
+
+	for _, input := range []any{bad{}, unexpectedParam{}} {
+		if err := ValidateStruct(input); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("ValidateStruct(%T) = %v; want ErrInvalidRule", input, err)
+		}
+	}
+}
+
+func TestLookupRule(t *testing.T) {
+	rule, err := LookupRule("test_prefix", "+1")
+	if err != nil {
+		t.Fatalf("LookupRule() error = %v", err)
+	}
+	if ve := rule("Mobile", "+44"); ve == nil || ve.Constraints["prefix"] != "+1" {
+		t.Errorf("rule(\"+44\") = %v; want a prefix error", ve)
+	}
+
+	if _, err := LookupRule("no_such_rule", ""); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("LookupRule(unknown) error = %v; want ErrInvalidRule", err)
+	}
+}
+
+func TestRegisterRule_Conflicts(t *testing.T) {
+	for _, name := range []string{"test_phone", "required", "min", "email"} {
+		if err := RegisterRule(name, phoneRule); !errors.Is(err, ErrDuplicateRule) {
+			t.Errorf("RegisterRule(%q) error = %v; want ErrDuplicateRule", name, err)
+		}
+	}
+	if err := RegisterRule("bad name", phoneRule); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("RegisterRule(\"bad name\") error = %v; want ErrInvalidRule", err)
+	}
+}
@@ -0,0 +1,91 @@
+package custom
+
+import "fmt"
+
+// Severity grades a ValidationError. The zero value is SeverityError so
+// existing errors keep blocking the request without being touched.
+type Severity int
+
+const (
+	SeverityError Severity = iota
+	SeverityWarning
+	SeverityFatal
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityFatal:
+		return "fatal"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+func (s *Severity) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "error", "":
+		*s = SeverityError
+	case "warning":
+		*s = SeverityWarning
+	case "fatal":
+		*s = SeverityFatal
+	default:
+		return fmt.Errorf("unknown severity %q", text)
+	}
+	return nil
+}
+
+// IsWarning reports whether err contains validation errors and all of them
+// are warnings, meaning the request may proceed.
+func IsWarning(err error) bool {
+	all := AllValidationErrors(err)
+	if len(all) == 0 {
+		return false
+	}
+	for _, ve := range all {
+		if ve.Severity != SeverityWarning {
+			return false
+		}
+	}
+	return true
+}
+
+// IsFatal reports whether any validation error in err is fatal.
+func IsFatal(err error) bool {
+	for _, ve := range AllValidationErrors(err) {
+		if ve.Severity == SeverityFatal {
+			return true
+		}
+	}
+	return false
+}
+
+// BySeverity returns the entries with exactly the given severity.
+func (e ValidationErrors) BySeverity(severity Severity) ValidationErrors {
+	var filtered ValidationErrors
+	for _, ve := range e {
+		if ve.Severity == severity {
+			filtered = append(filtered, ve)
+		}
+	}
+	return filtered
+}
+
+// Blocking returns the entries that should stop the request, i.e. every
+// entry that is not a warning.
+func (e ValidationErrors) Blocking() ValidationErrors {
+	var filtered ValidationErrors
+	for _, ve := range e {
+		if ve.Severity != SeverityWarning {
+			filtered = append(filtered, ve)
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,94 @@
+package custom
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSeverity_DefaultIsError(t *testing.T) {
+	var ve ValidationError
+	if ve.Severity != SeverityError {
+		t.Errorf("zero ValidationError severity = %v; want %v", ve.Severity, SeverityError)
+	}
+}
+
+func TestIsWarning(t *testing.T) {
+	warning := &ValidationError{Field: "Age", Code: 2001, Severity: SeverityWarning}
+	otherWarning := &ValidationError{Field: "Nickname", Code: 3001, Severity: SeverityWarning}
+	blocking := &ValidationError{Field: "Email", Code: 2003}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"single warning", warning, true},
+		{"only warnings", ValidationErrors{warning, otherWarning}, true},
+		{"warning mixed with error", ValidationErrors{warning, blocking}, false},
+		{"plain error", errors.New("plain"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsWarning(tt.err); got != tt.expected {
+				t.Errorf("IsWarning() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsFatal(t *testing.T) {
+	fatal := &ValidationError{Field: "Payload", Code: 9001, Severity: SeverityFatal}
+	warning := &ValidationError{Field: "Age", Code: 2001, Severity: SeverityWarning}
+
+	if !IsFatal(ValidationErrors{warning, fatal}) {
+		t.Error("IsFatal should detect a fatal entry")
+	}
+	if IsFatal(warning) {
+		t.Error("IsFatal(warning) should be false")
+	}
+}
+
+func TestValidationErrors_FilterBySeverity(t *testing.T) {
+	warning := &ValidationError{Field: "Age", Severity: SeverityWarning}
+	blocking := &ValidationError{Field: "Email", Severity: SeverityError}
+	fatal := &ValidationError{Field: "Payload", Severity: SeverityFatal}
+	errs := ValidationErrors{warning, blocking, fatal}
+
+	if got := errs.BySeverity(SeverityWarning); len(got) != 1 || got[0] != warning {
+		t.Errorf("BySeverity(warning) = %v; want [warning]", got)
+	}
+	if got := errs.Blocking(); len(got) != 2 || got[0] != blocking || got[1] != fatal {
+		t.Errorf("Blocking() = %v; want [error fatal]", got)
+	}
+}
+
+func TestSeverity_JSON(t *testing.T) {
+	data, err := json.Marshal(&ValidationError{Field: "Age", Code: 2001, Severity: SeverityWarning})
+	if err != nil {
+		t.Fatalf("json.Marshal returned unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"severity":"warning"`) {
+		t.Errorf("json.Marshal = %s; want severity warning", data)
+	}
+
+	var decoded ValidationError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal returned unexpected error: %v", err)
+	}
+	if decoded.Severity != SeverityWarning {
+		t.Errorf("decoded severity = %v; want warning", decoded.Severity)
+	}
+
+	plain, _ := json.Marshal(&ValidationError{Field: "Age", Code: 2001})
+	if strings.Contains(string(plain), "severity") {
+		t.Errorf("default severity should be omitted, got %s", plain)
+	}
+
+	if err := json.Unmarshal([]byte(`{"field":"Age","severity":"urgent"}`), &decoded); err == nil {
+		t.Error("json.Unmarshal with unknown severity expected error but got none")
+	}
+}
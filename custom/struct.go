@@ -0,0 +1,196 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Codes emitted by ValidateStruct, one per rule so clients can branch on
+// the rule that failed regardless of which field carried the tag.
+const (
+	CodeRuleRequired = 4001
+	CodeRuleMin      = 4002
+	CodeRuleMax      = 4003
+)
+
+// ErrInvalidRule reports a malformed validate tag. It signals a programming
+// mistake in the struct definition, not invalid input.
+var ErrInvalidRule = errors.New("invalid validation rule")
+
+// ValidateStruct checks the `validate` tags on v's exported fields and
+// returns every failure as ValidationErrors, or nil when v is valid.
+// Nested structs and slices of structs are validated too, with dotted and
+// indexed field paths. A pointer back to a struct already being validated,
+// as in a parent/child graph, is not followed again.
+// Built-in rules are "required" (non-zero value) and "min=N"/"max=N",
+// which compare numbers by value and strings, slices and maps by length.
+// Any other rule name is resolved through RegisterRule, which already
+// provides "email" and "oneof=a b c".
+func ValidateStruct(v any) error {
+	rv := reflect.ValueOf(v)
+	path := make(map[visit]struct{})
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return fmt.Errorf("ValidateStruct: nil %T: %w", v, ErrInvalidRule)
+		}
+		path[visit{rv.Pointer(), rv.Type()}] = struct{}{}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("ValidateStruct: expected struct, got %T: %w", v, ErrInvalidRule)
+	}
+
+	errs, err := validateStructValue(rv, path)
+	if err != nil {
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// visit identifies a pointer being followed. The type is part of the key
+// because a struct and its first field share an address.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// validateStructValue applies field tags and descends into nested structs
+// and slices of structs, reporting paths such as "Items[3].Price". path
+// holds the pointers followed to reach rv.
+func validateStructValue(rv reflect.Value, path map[visit]struct{}) (ValidationErrors, error) {
+	var errs ValidationErrors
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if tag, ok := field.Tag.Lookup("validate"); ok {
+			for _, rule := range strings.Split(tag, ",") {
+				ve, err := applyRule(field.Name, rv.Field(i), strings.TrimSpace(rule))
+				if err != nil {
+					return nil, err
+				}
+				if ve != nil {
+					errs = append(errs, ve)
+				}
+			}
+		}
+		nested, err := validateNested(rv.Field(i), path)
+		if err != nil {
+			return nil, err
+		}
+		errs = append(errs, nested.WithParent(field.Name)...)
+	}
+	return errs, nil
+}
+
+func validateNested(value reflect.Value, path map[visit]struct{}) (ValidationErrors, error) {
+	if value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return nil, nil
+		}
+		key := visit{value.Pointer(), value.Type()}
+		if _, cycle := path[key]; cycle {
+			return nil, nil
+		}
+		path[key] = struct{}{}
+		defer delete(path, key)
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Struct:
+		return validateStructValue(value, path)
+	case reflect.Slice, reflect.Array:
+		var errs ValidationErrors
+		for i := 0; i < value.Len(); i++ {
+			nested, err := validateNested(value.Index(i), path)
+			if err != nil {
+				return nil, err
+			}
+			errs = append(errs, nested.WithParent(fmt.Sprintf("[%d]", i))...)
+		}
+		return errs, nil
+	}
+	return nil, nil
+}
+
+func applyRule(name string, value reflect.Value, rule string) (*ValidationError, error) {
+	ruleName, param, _ := strings.Cut(rule, "=")
+	switch ruleName {
+	case "":
+		return nil, nil
+	case "required":
+		if value.IsZero() {
+			return &ValidationError{
+				Field:       name,
+				Message:     name + " is required",
+				Code:        CodeRuleRequired,
+				Value:       value.Interface(),
+				Constraints: map[string]any{"required": true},
+			}, nil
+		}
+		return nil, nil
+	case "min", "max":
+		bound, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q on field %s: %w", rule, name, ErrInvalidRule)
+		}
+		measured, isLength, ok := measure(value)
+		if !ok {
+			return nil, fmt.Errorf("rule %q on field %s of kind %s: %w", rule, name, value.Kind(), ErrInvalidRule)
+		}
+		var ve *ValidationError
+		if ruleName == "min" && measured < bound {
+			ve = boundError(name, value, isLength, "less than", "at least", param, CodeRuleMin)
+		}
+		if ruleName == "max" && measured > bound {
+			ve = boundError(name, value, isLength, "greater than", "at most", param, CodeRuleMax)
+		}
+		if ve != nil {
+			ve.Constraints = map[string]any{ruleName: bound}
+			if isLength {
+				ve.Constraints["length"] = true
+			}
+		}
+		return ve, nil
+	}
+	registered, err := LookupRule(ruleName, param)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", name, err)
+	}
+	return registered(name, value.Interface()), nil
+}
+
+// measure returns the number compared against min/max bounds and whether
+// it is a length rather than the value itself.
+func measure(value reflect.Value) (float64, bool, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), false, true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(value.Uint()), false, true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), false, true
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		return float64(value.Len()), true, true
+	}
+	return 0, false, false
+}
+
+func boundError(name string, value reflect.Value, isLength bool, valueWord, lengthWord, bound string, code int) *ValidationError {
+	message := fmt.Sprintf("%s cannot be %s %s", name, valueWord, bound)
+	if isLength {
+		message = fmt.Sprintf("%s must have %s %s elements", name, lengthWord, bound)
+		if value.Kind() == reflect.String {
+			message = fmt.Sprintf("%s must be %s %s characters", name, lengthWord, bound)
+		}
+	}
+	return &ValidationError{Field: name, Message: message, Code: code, Value: value.Interface()}
+}
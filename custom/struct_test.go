@@ -0,0 +1,144 @@
+package custom
+
+import (
+	"errors"
+	"testing"
+)
+
+type signupForm struct {
+	Email    string   `validate:"required"`
+	Age      int      `validate:"min=0,max=130"`
+	Password string   `validate:"required,min=8"`
+	Tags     []string `validate:"max=2"`
+	Score    float64  `validate:"min=0.5"`
+	Note     string
+}
+
+func TestValidateStruct_Valid(t *testing.T) {
+	form := signupForm{Email: "a@b.c", Age: 30, Password: "correct horse", Tags: []string{"go"}, Score: 1}
+
+	if err := ValidateStruct(form); err != nil {
+		t.Errorf("ValidateStruct(valid) returned unexpected error: %v", err)
+	}
+	if err := ValidateStruct(&form); err != nil {
+		t.Errorf("ValidateStruct(&valid) returned unexpected error: %v", err)
+	}
+}
+
+func TestValidateStruct_Failures(t *testing.T) {
+	form := signupForm{Email: "", Age: 200, Password: "short", Tags: []string{"a", "b", "c"}, Score: 0.1}
+
+	err := ValidateStruct(&form)
+
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("ValidateStruct = %T; want ValidationErrors", err)
+	}
+
+	expected := []struct {
+		field   string
+		code    int
+		message string
+	}{
+		{"Email", CodeRuleRequired, "Email is required"},
+		{"Age", CodeRuleMax, "Age cannot be greater than 130"},
+		{"Password", CodeRuleMin, "Password must be at least 8 characters"},
+		{"Tags", CodeRuleMax, "Tags must have at most 2 elements"},
+		{"Score", CodeRuleMin, "Score cannot be less than 0.5"},
+	}
+	if len(errs) != len(expected) {
+		t.Fatalf("ValidateStruct returned %d errors; want %d: %v", len(errs), len(expected), err)
+	}
+	for i, want := range expected {
+		if errs[i].Field != want.field || errs[i].Code != want.code || errs[i].Message != want.message {
+			t.Errorf("error %d = (%s, %d, %q); want (%s, %d, %q)",
+				i, errs[i].Field, errs[i].Code, errs[i].Message, want.field, want.code, want.message)
+		}
+	}
+	if errs[1].Value != 200 {
+		t.Errorf("Age error value = %v; want 200", errs[1].Value)
+	}
+}
+
+func TestValidateStruct_InvalidInput(t *testing.T) {
+	type badRule struct {
+		Name string `validate:"pattern=x"`
+	}
+	type badParam struct {
+		Age int `validate:"min=abc"`
+	}
+	type badKind struct {
+		Flag bool `validate:"min=1"`
+	}
+	var nilForm *signupForm
+
+	inputs := []any{42, nilForm, badRule{}, badParam{}, badKind{}}
+	for _, input := range inputs {
+		err := ValidateStruct(input)
+		if !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("ValidateStruct(%T) = %v; want ErrInvalidRule", input, err)
+		}
+		if len(AllValidationErrors(err)) != 0 {
+			t.Errorf("ValidateStruct(%T) should not report input validation errors", input)
+		}
+	}
+}
+
+type orderAddress struct {
+	City string `validate:"required"`
+}
+
+type orderItem struct {
+	Name  string  `validate:"required"`
+	Price float64 `validate:"min=0"`
+}
+
+type order struct {
+	Address  orderAddress
+	Billing  *orderAddress
+	Items    []orderItem
+	Previous *order
+}
+
+func TestValidateStruct_NestedPaths(t *testing.T) {
+	o := order{
+		Address: orderAddress{City: ""},
+		Billing: &orderAddress{City: ""},
+		Items: []orderItem{
+			{Name: "book", Price: 10},
+			{Name: "", Price: -1},
+		},
+	}
+
+	err := ValidateStruct(o)
+
+	var fields []string
+	for _, ve := range AllValidationErrors(err) {
+		fields = append(fields, ve.Field)
+	}
+
+	expected := []string{"Address.City", "Billing.City", "Items[1].Name", "Items[1].Price"}
+	if len(fields) != len(expected) {
+		t.Fatalf("ValidateStruct(nested) fields = %v; want %v", fields, expected)
+	}
+	for i := range expected {
+		if fields[i] != expected[i] {
+			t.Errorf("field %d = %q; want %q", i, fields[i], expected[i])
+		}
+	}
+}
+
+func TestValidateStruct_Cycle(t *testing.T) {
+	o := &order{Address: orderAddress{City: ""}, Billing: &orderAddress{City: "Oslo"}}
+	o.Previous = o
+
+	err := ValidateStruct(o)
+
+	var fields []string
+	for _, ve := range AllValidationErrors(err) {
+		fields = append(fields, ve.Field)
+	}
+	if len(fields) != 1 || fields[0] != "Address.City" {
+		t.Fatalf("ValidateStruct(cyclic) fields = %v; want [Address.City]", fields)
+	}
+}
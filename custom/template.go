@@ -0,0 +1,119 @@
+package custom
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// messageTemplates holds the message templates registered per code.
+var messageTemplates = struct {
+	sync.RWMutex
+	byCode map[int]*template.Template
+}{byCode: make(map[int]*template.Template)}
+
+// templateData is what message templates see. It mirrors ValidationError
+// without its methods so a template cannot recurse back into Error().
+type templateData struct {
+	Field       string
+	Message     string
+	Code        int
+	Value       interface{}
+	Severity    Severity
+	Hint        string
+	Constraints map[string]any
+}
+
+// RegisterMessageTemplate sets the Go template used for errors with code
+// whose Message is empty, e.g. "{{.Field}} must be at most
+// {{.Constraints.max}}". Registering a code again replaces its template.
+func RegisterMessageTemplate(code int, text string) error {
+	tmpl, err := parseMessageTemplate(code, text)
+	if err != nil {
+		return err
+	}
+	messageTemplates.Lock()
+	defer messageTemplates.Unlock()
+	messageTemplates.byCode[code] = tmpl
+	return nil
+}
+
+// MustRegisterMessageTemplate is RegisterMessageTemplate for package
+// initialization; it panics on a malformed template.
+func MustRegisterMessageTemplate(code int, text string) {
+	if err := RegisterMessageTemplate(code, text); err != nil {
+		panic(err)
+	}
+}
+
+// WithMessageTemplate returns a copy of e whose message is rendered from
+// the Go template text, e.g. "{{.Field}} must be at most
+// {{.Constraints.max}}". The template is parsed once, here; Message holds
+// the raw text. Other Messages are never executed, so text from other
+// services or users is safe to put in Message even if it contains "{{".
+func (e *ValidationError) WithMessageTemplate(text string) (*ValidationError, error) {
+	tmpl, err := parseMessageTemplate(e.Code, text)
+	if err != nil {
+		return nil, err
+	}
+	templated := e.Clone()
+	templated.Message = text
+	templated.messageTemplate = tmpl
+	return templated, nil
+}
+
+// ResolvedMessage returns the message Error() reports: the template set
+// with WithMessageTemplate, then an explicit Message as literal text, then
+// the template registered for Code, then the registry description. A
+// template that fails to render falls back to its raw text.
+func (e *ValidationError) ResolvedMessage() string {
+	if e.messageTemplate != nil {
+		return e.render(e.messageTemplate, e.Message)
+	}
+	if e.Message != "" {
+		return e.Message
+	}
+
+	if message, ok := e.registeredMessage(); ok {
+		return message
+	}
+	message, _ := Describe(e.Code)
+	return message
+}
+
+// registeredMessage renders the template registered for e.Code, if any.
+func (e *ValidationError) registeredMessage() (string, bool) {
+	messageTemplates.RLock()
+	tmpl, ok := messageTemplates.byCode[e.Code]
+	messageTemplates.RUnlock()
+	if !ok {
+		return "", false
+	}
+	return e.render(tmpl, tmpl.Root.String()), true
+}
+
+func (e *ValidationError) render(tmpl *template.Template, fallback string) string {
+	var b strings.Builder
+	err := tmpl.Execute(&b, templateData{
+		Field:       e.Field,
+		Message:     e.Message,
+		Code:        e.Code,
+		Value:       e.DisplayValue(),
+		Severity:    e.Severity,
+		Hint:        e.Hint,
+		Constraints: e.Constraints,
+	})
+	if err != nil {
+		return fallback
+	}
+	return b.String()
+}
+
+func parseMessageTemplate(code int, text string) (*template.Template, error) {
+	tmpl, err := template.New(fmt.Sprintf("code-%d", code)).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid message template for code %d: %w", code, err)
+	}
+	return tmpl, nil
+}
@@ -0,0 +1,110 @@
+package custom
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResolvedMessage_InlineTemplate(t *testing.T) {
+	base := &ValidationError{
+		Field:       "Age",
+		Code:        CodeRuleMax,
+		Value:       131,
+		Constraints: map[string]any{"max": 130},
+	}
+	err, tmplErr := base.WithMessageTemplate("{{.Field}} must be at most {{.Constraints.max}}")
+	if tmplErr != nil {
+		t.Fatalf("WithMessageTemplate() error = %v", tmplErr)
+	}
+	if base.messageTemplate != nil || base.Message != "" {
+		t.Error("WithMessageTemplate should leave the original unchanged")
+	}
+
+	if got := err.ResolvedMessage(); got != "Age must be at most 130" {
+		t.Errorf("ResolvedMessage() = %q; want %q", got, "Age must be at most 130")
+	}
+	if !strings.Contains(err.Error(), "Age must be at most 130") {
+		t.Errorf("Error() = %q; want the rendered template", err.Error())
+	}
+}
+
+func TestResolvedMessage_RegisteredTemplate(t *testing.T) {
+	const code = 4990
+	if err := RegisterMessageTemplate(code, "{{.Field}} got {{.Value}}, limit {{.Constraints.max}}"); err != nil {
+		t.Fatalf("RegisterMessageTemplate() error = %v", err)
+	}
+	defer unregisterMessageTemplate(code)
+
+	err := &ValidationError{Field: "Count", Code: code, Value: 12, Constraints: map[string]any{"max": 10}}
+	if got := err.ResolvedMessage(); got != "Count got 12, limit 10" {
+		t.Errorf("ResolvedMessage() = %q; want %q", got, "Count got 12, limit 10")
+	}
+
+	explicit := &ValidationError{Field: "Count", Code: code, Message: "too many"}
+	if got := explicit.ResolvedMessage(); got != "too many" {
+		t.Errorf("ResolvedMessage() with explicit Message = %q; want %q", got, "too many")
+	}
+}
+
+func TestResolvedMessage_MissingConstraint(t *testing.T) {
+	err, _ := (&ValidationError{Field: "Age"}).WithMessageTemplate("{{.Field}} must be at most {{.Constraints.max}}")
+	if got := err.ResolvedMessage(); got != "Age must be at most <no value>" {
+		t.Errorf("ResolvedMessage() = %q; want missing keys rendered as <no value>", got)
+	}
+}
+
+func TestWithMessageTemplate_Invalid(t *testing.T) {
+	if _, err := (&ValidationError{Field: "Age"}).WithMessageTemplate("{{.Field"); err == nil {
+		t.Error("WithMessageTemplate() expected parse error but got none")
+	}
+}
+
+func TestResolvedMessage_ExplicitMessageIsLiteral(t *testing.T) {
+	// A message received from another service must not be executed.
+	remote := &ValidationError{Field: "Name", Message: `must not contain {{.Field}} or {{printf "%s" "x"}}`}
+	if got := remote.ResolvedMessage(); got != remote.Message {
+		t.Errorf("ResolvedMessage() = %q; want the message unchanged", got)
+	}
+
+	const code = 4992
+	if err := RegisterMessageTemplate(code, "{{.Field}} is wrong"); err != nil {
+		t.Fatalf("RegisterMessageTemplate() error = %v", err)
+	}
+	defer unregisterMessageTemplate(code)
+	braces := &ValidationError{Field: "Name", Code: code, Message: "use {{ }} sparingly"}
+	if got := braces.ResolvedMessage(); got != "use {{ }} sparingly" {
+		t.Errorf("ResolvedMessage() = %q; want the explicit message over the registered template", got)
+	}
+}
+
+func TestWithMessageTemplate_JSONSendsRenderedMessage(t *testing.T) {
+	err, _ := (&ValidationError{Field: "Age", Code: CodeRuleMax}).WithMessageTemplate("{{.Field}} is too large")
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatalf("json.Marshal() error = %v", jsonErr)
+	}
+	if !strings.Contains(string(data), `"message":"Age is too large"`) {
+		t.Errorf("MarshalJSON() = %s; want the rendered message", data)
+	}
+}
+
+func TestResolvedMessage_FallsBackToDescribe(t *testing.T) {
+	err := &ValidationError{Field: "Age", Code: CodeRuleRequired}
+	want, _ := Describe(CodeRuleRequired)
+	if got := err.ResolvedMessage(); got != want {
+		t.Errorf("ResolvedMessage() = %q; want %q", got, want)
+	}
+}
+
+func TestRegisterMessageTemplate_Invalid(t *testing.T) {
+	if err := RegisterMessageTemplate(4991, "{{.Field"); err == nil {
+		t.Error("RegisterMessageTemplate() expected parse error but got none")
+	}
+}
+
+func unregisterMessageTemplate(code int) {
+	messageTemplates.Lock()
+	defer messageTemplates.Unlock()
+	delete(messageTemplates.byCode, code)
+}
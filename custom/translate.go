@@ -0,0 +1,84 @@
+package custom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Translator renders a validation error for a language. It reports false
+// when it has no message for that language and code.
+type Translator interface {
+	Translate(lang string, e *ValidationError) (string, bool)
+}
+
+// Catalog is a Translator backed by message templates keyed by language and
+// error code. Templates may use {field} and {value} placeholders.
+type Catalog map[string]map[int]string
+
+// Translate looks up lang, falling back from a regional tag such as "es-MX"
+// to its base language "es".
+func (c Catalog) Translate(lang string, e *ValidationError) (string, bool) {
+	lang = strings.ToLower(lang)
+	for _, candidate := range []string{lang, baseLanguage(lang)} {
+		if template, ok := c[candidate][e.Code]; ok {
+			return interpolate(template, e), true
+		}
+	}
+	return "", false
+}
+
+func baseLanguage(lang string) string {
+	if base, _, found := strings.Cut(lang, "-"); found {
+		return base
+	}
+	base, _, _ := strings.Cut(lang, "_")
+	return base
+}
+
+func interpolate(template string, e *ValidationError) string {
+	return strings.NewReplacer(
+		"{field}", e.Field,
+		"{value}", fmt.Sprint(e.DisplayValue()),
+	).Replace(template)
+}
+
+// DefaultCatalog covers the codes used across this module.
+var DefaultCatalog = Catalog{
+	"en": {
+		1001:             "{field} cannot be negative (got {value})",
+		1002:             "{field} cannot be greater than 100 (got {value})",
+		2001:             "{field} cannot be negative (got {value})",
+		2002:             "{field} cannot be greater than 130 (got {value})",
+		2003:             "{field} cannot be empty",
+		2013:             "{field} is not a valid email address: {value}",
+		CodeRuleRequired: "{field} is required",
+		CodeRuleMin:      "{field} is below the allowed minimum (got {value})",
+		CodeRuleMax:      "{field} is above the allowed maximum (got {value})",
+	},
+	"es": {
+		1001:             "{field} no puede ser negativo (valor: {value})",
+		1002:             "{field} no puede ser mayor que 100 (valor: {value})",
+		2001:             "{field} no puede ser negativo (valor: {value})",
+		2002:             "{field} no puede ser mayor que 130 (valor: {value})",
+		2003:             "{field} no puede estar vacío",
+		2013:             "{field} no es una dirección de correo válida: {value}",
+		CodeRuleRequired: "{field} es obligatorio",
+		CodeRuleMin:      "{field} está por debajo del mínimo permitido (valor: {value})",
+		CodeRuleMax:      "{field} está por encima del máximo permitido (valor: {value})",
+	},
+}
+
+// DefaultTranslator is used by LocalizedMessage. Replace it to plug in an
+// external translation service.
+var DefaultTranslator Translator = DefaultCatalog
+
+// LocalizedMessage renders the message in lang via DefaultTranslator and
+// falls back to ResolvedMessage when no translation exists.
+func (e *ValidationError) LocalizedMessage(lang string) string {
+	if DefaultTranslator != nil {
+		if msg, ok := DefaultTranslator.Translate(lang, e); ok {
+			return msg
+		}
+	}
+	return e.ResolvedMessage()
+}
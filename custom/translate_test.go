@@ -0,0 +1,57 @@
+package custom
+
+import "testing"
+
+func TestValidationError_LocalizedMessage(t *testing.T) {
+	err := &ValidationError{Field: "Age", Message: "Age cannot be negative", Code: 2001, Value: -5}
+
+	tests := []struct {
+		lang     string
+		expected string
+	}{
+		{"en", "Age cannot be negative (got -5)"},
+		{"es", "Age no puede ser negativo (valor: -5)"},
+		{"es-MX", "Age no puede ser negativo (valor: -5)"},
+		{"ES_es", "Age no puede ser negativo (valor: -5)"},
+		{"de", "Age cannot be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.lang, func(t *testing.T) {
+			if got := err.LocalizedMessage(tt.lang); got != tt.expected {
+				t.Errorf("LocalizedMessage(%q) = %q; want %q", tt.lang, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidationError_LocalizedMessageUnknownCode(t *testing.T) {
+	err := &ValidationError{Field: "Nickname", Message: "Nickname is taken", Code: 9999}
+
+	if got := err.LocalizedMessage("es"); got != "Nickname is taken" {
+		t.Errorf("LocalizedMessage(unknown code) = %q; want the original message", got)
+	}
+}
+
+type upperTranslator struct{}
+
+func (upperTranslator) Translate(lang string, e *ValidationError) (string, bool) {
+	if lang != "shout" {
+		return "", false
+	}
+	return "INVALID " + e.Field, true
+}
+
+func TestDefaultTranslator_Replaceable(t *testing.T) {
+	original := DefaultTranslator
+	defer func() { DefaultTranslator = original }()
+	DefaultTranslator = upperTranslator{}
+
+	err := &ValidationError{Field: "Email", Message: "Email cannot be empty", Code: 2003}
+	if got := err.LocalizedMessage("shout"); got != "INVALID Email" {
+		t.Errorf("LocalizedMessage with custom translator = %q; want %q", got, "INVALID Email")
+	}
+	if got := err.LocalizedMessage("en"); got != "Email cannot be empty" {
+		t.Errorf("LocalizedMessage fallback = %q; want the original message", got)
+	}
+}
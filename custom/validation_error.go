@@ -1,14 +1,99 @@
 package custom
 
-import "fmt"
+import (
+	"fmt"
+	"text/template"
+)
 
 type ValidationError struct {
 	Field   string
 	Message string
 	Code    int
 	Value   interface{}
+	// Severity defaults to SeverityError; warnings describe soft problems
+	// that should be reported without rejecting the request.
+	Severity Severity
+	// Hint tells the caller how to fix the input, e.g. "age must be
+	// between 0 and 130".
+	Hint string
+	// Constraints carries the rule parameters that failed (e.g. "min",
+	// "max") so API layers can render them without parsing Message.
+	Constraints map[string]any
+	// Err is the underlying failure, if any, such as a regexp compile
+	// error or a failed DNS lookup while checking an email domain.
+	Err error
+	// Sensitive keeps Value out of Error(), logs and JSON, e.g. for
+	// passwords and tokens. See also SensitiveFields.
+	Sensitive bool
+
+	// messageTemplate is the parsed template from WithMessageTemplate.
+	messageTemplate *template.Template
 }
 
 func (e *ValidationError) Error() string {
-	return fmt.Sprintf("Validation error on field '%s': %s (code: %d, value: %v)", e.Field, e.Message, e.Code, e.Value)
+	// Errors built from a bare code still get a meaningful message
+	message := e.ResolvedMessage()
+	msg := fmt.Sprintf("Validation error on field '%s': %s (code: %d, value: %v)", e.Field, message, e.Code, e.DisplayValue())
+	if e.Hint != "" {
+		msg += " hint: " + e.Hint
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+// UserFacing returns the message to show end users: the resolved message
+// without field, code or value, which may be sensitive or internal.
+func (e *ValidationError) UserFacing() string {
+	if message := e.ResolvedMessage(); message != "" {
+		return message
+	}
+	return fmt.Sprintf("%s is invalid", e.Field)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Clone returns a copy of e that shares nothing mutable with it: the
+// Constraints map is copied, while Value and Err are kept as-is.
+func (e *ValidationError) Clone() *ValidationError {
+	clone := *e
+	if e.Constraints != nil {
+		clone.Constraints = make(map[string]any, len(e.Constraints))
+		for k, v := range e.Constraints {
+			clone.Constraints[k] = v
+		}
+	}
+	return &clone
+}
+
+// WithHint returns a copy of e carrying hint.
+func (e *ValidationError) WithHint(hint string) *ValidationError {
+	hinted := e.Clone()
+	hinted.Hint = hint
+	return hinted
+}
+
+// WithField returns a copy of e reported against field, e.g. to map an
+// internal struct field name to its API name.
+func (e *ValidationError) WithField(field string) *ValidationError {
+	renamed := e.Clone()
+	renamed.Field = field
+	return renamed
+}
+
+// WithCode returns a copy of e carrying code.
+func (e *ValidationError) WithCode(code int) *ValidationError {
+	recoded := e.Clone()
+	recoded.Code = code
+	return recoded
+}
+
+// WithValue returns a copy of e carrying value.
+func (e *ValidationError) WithValue(value interface{}) *ValidationError {
+	revalued := e.Clone()
+	revalued.Value = value
+	return revalued
 }
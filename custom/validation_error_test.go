@@ -1,6 +1,9 @@
 package custom
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"testing"
 )
 
@@ -103,3 +106,109 @@ func TestValidationError_Fields(t *testing.T) {
 		t.Errorf("ValidationError.Value = %v; want %v", err.Value, "123")
 	}
 }
+
+func TestValidationError_Hint(t *testing.T) {
+	original := &ValidationError{Field: "age", Message: "Age must be positive", Code: 1002, Value: -5}
+
+	hinted := original.WithHint("age must be between 0 and 130")
+
+	expectedMsg := "Validation error on field 'age': Age must be positive (code: 1002, value: -5) hint: age must be between 0 and 130"
+	if hinted.Error() != expectedMsg {
+		t.Errorf("ValidationError.Error() = %v; want %v", hinted.Error(), expectedMsg)
+	}
+	if original.Hint != "" {
+		t.Error("WithHint should not modify the original error")
+	}
+
+	data, err := json.Marshal(hinted)
+	if err != nil {
+		t.Fatalf("json.Marshal returned unexpected error: %v", err)
+	}
+	var decoded ValidationError
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal returned unexpected error: %v", err)
+	}
+	if decoded.Hint != hinted.Hint {
+		t.Errorf("decoded Hint = %q; want %q", decoded.Hint, hinted.Hint)
+	}
+
+	built, _ := NewValidation("age").Code(1002).Hint("use a whole number").Build()
+	if built.Hint != "use a whole number" {
+		t.Errorf("builder Hint = %q; want %q", built.Hint, "use a whole number")
+	}
+}
+
+func TestValidationError_Unwrap(t *testing.T) {
+	errLookup := errors.New("lookup example.invalid: no such host")
+	err := &ValidationError{Field: "Email", Message: "Email domain does not resolve", Code: 2014, Value: "a@example.invalid", Err: errLookup}
+
+	if !errors.Is(err, errLookup) {
+		t.Error("errors.Is should reach the wrapped cause")
+	}
+	if !errors.Is(fmt.Errorf("signup: %w", err), Code(2014)) {
+		t.Error("errors.Is should still match the code through a wrapper")
+	}
+
+	expectedMsg := "Validation error on field 'Email': Email domain does not resolve (code: 2014, value: a@example.invalid): lookup example.invalid: no such host"
+	if err.Error() != expectedMsg {
+		t.Errorf("ValidationError.Error() = %v; want %v", err.Error(), expectedMsg)
+	}
+
+	data, marshalErr := json.Marshal(err)
+	if marshalErr != nil {
+		t.Fatalf("json.Marshal returned unexpected error: %v", marshalErr)
+	}
+	var decoded ValidationError
+	if unmarshalErr := json.Unmarshal(data, &decoded); unmarshalErr != nil {
+		t.Fatalf("json.Unmarshal returned unexpected error: %v", unmarshalErr)
+	}
+	if decoded.Err == nil || decoded.Err.Error() != errLookup.Error() {
+		t.Errorf("decoded cause = %v; want %v", decoded.Err, errLookup)
+	}
+
+	built, _ := NewValidation("Email").Code(2014).Cause(errLookup).Build()
+	if built.Unwrap() != errLookup {
+		t.Errorf("builder Cause = %v; want %v", built.Unwrap(), errLookup)
+	}
+}
+
+func TestValidationError_UnwrapNil(t *testing.T) {
+	err := &ValidationError{Field: "Age", Code: 2001}
+	if err.Unwrap() != nil {
+		t.Errorf("Unwrap() without a cause = %v; want nil", err.Unwrap())
+	}
+}
+
+func TestValidationError_Clone(t *testing.T) {
+	original := &ValidationError{
+		Field:       "age",
+		Message:     "Age too large",
+		Code:        2002,
+		Value:       131,
+		Constraints: map[string]any{"max": 130},
+	}
+
+	clone := original.Clone()
+	clone.Constraints["max"] = 200
+	clone.Field = "years"
+
+	if original.Constraints["max"] != 130 {
+		t.Error("Clone should copy Constraints instead of sharing the map")
+	}
+	if original.Field != "age" {
+		t.Error("Clone should not alias the original error")
+	}
+}
+
+func TestValidationError_CopyOnWrite(t *testing.T) {
+	original := &ValidationError{Field: "user_age", Message: "Age too large", Code: 2002, Value: 131}
+
+	renamed := original.WithField("age").WithCode(4003).WithValue(140)
+
+	if renamed.Field != "age" || renamed.Code != 4003 || renamed.Value != 140 || renamed.Message != "Age too large" {
+		t.Errorf("chained copy = %+v; want field age, code 4003, value 140", *renamed)
+	}
+	if original.Field != "user_age" || original.Code != 2002 || original.Value != 131 {
+		t.Errorf("original = %+v; want it unchanged", *original)
+	}
+}
@@ -0,0 +1,33 @@
+package custom
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationErrors collects every failure found in one validation pass so
+// callers can report all problems at once instead of one per round trip.
+// errors.Is and errors.As see each entry through Unwrap.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	switch len(e) {
+	case 0:
+		return "no validation errors"
+	case 1:
+		return e[0].Error()
+	}
+	messages := make([]string, len(e))
+	for i, ve := range e {
+		messages[i] = ve.Error()
+	}
+	return fmt.Sprintf("%d validation errors: %s", len(e), strings.Join(messages, "; "))
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, ve := range e {
+		errs[i] = ve
+	}
+	return errs
+}
@@ -0,0 +1,49 @@
+package custom
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationErrors_Error(t *testing.T) {
+	age := &ValidationError{Field: "Age", Message: "Age cannot be less than 0", Code: 2001, Value: -1}
+	email := &ValidationError{Field: "Email", Message: "Email cannot be empty", Code: 2003, Value: ""}
+
+	tests := []struct {
+		name        string
+		errs        ValidationErrors
+		expectedMsg string
+	}{
+		{"empty", ValidationErrors{}, "no validation errors"},
+		{"single", ValidationErrors{age}, age.Error()},
+		{"multiple", ValidationErrors{age, email}, "2 validation errors: " + age.Error() + "; " + email.Error()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.errs.Error(); result != tt.expectedMsg {
+				t.Errorf("ValidationErrors.Error() = %v; want %v", result, tt.expectedMsg)
+			}
+		})
+	}
+}
+
+func TestValidationErrors_Unwrap(t *testing.T) {
+	age := &ValidationError{Field: "Age", Code: 2001, Value: -1}
+	email := &ValidationError{Field: "Email", Code: 2003, Value: ""}
+	var err error = ValidationErrors{age, email}
+
+	if !errors.Is(err, email) {
+		t.Error("errors.Is should find an individual entry")
+	}
+
+	var target *ValidationError
+	if !errors.As(err, &target) || target != age {
+		t.Error("errors.As should extract the first entry")
+	}
+
+	all := AllValidationErrors(err)
+	if len(all) != 2 || all[0] != age || all[1] != email {
+		t.Errorf("AllValidationErrors(ValidationErrors) = %v; want both entries in order", all)
+	}
+}
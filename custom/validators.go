@@ -0,0 +1,133 @@
+package custom
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// Codes emitted by the built-in rules in addition to CodeRuleRequired,
+// CodeRuleMin and CodeRuleMax.
+const (
+	CodeRuleEmail   = 4005
+	CodeRulePattern = 4006
+	CodeRuleOneOf   = 4007
+	CodeRuleType    = 4008
+)
+
+// Rule checks one value and returns a ValidationError for field, or nil.
+// Rules are the building blocks shared by every validator in this module
+// so bounds and formats are defined once with consistent codes.
+type Rule func(field string, value any) *ValidationError
+
+// Required rejects nil and zero values.
+func Required(field string, value any) *ValidationError {
+	if value == nil || reflect.ValueOf(value).IsZero() {
+		return &ValidationError{
+			Field:       field,
+			Message:     field + " is required",
+			Code:        CodeRuleRequired,
+			Value:       value,
+			Constraints: map[string]any{"required": true},
+		}
+	}
+	return nil
+}
+
+// Range accepts integers between min and max inclusive, reporting
+// CodeRuleMin or CodeRuleMax for the violated bound.
+func Range(min, max int) Rule {
+	return func(field string, value any) *ValidationError {
+		rv := reflect.ValueOf(value)
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			n := rv.Int()
+			if n < int64(min) || n > int64(max) {
+				ve := ValidateBounds(field, int(n), min, max, CodeRuleMin, CodeRuleMax)
+				ve.Value = value
+				return ve
+			}
+			return nil
+		}
+		return typeError(field, value, "an integer")
+	}
+}
+
+// Email accepts strings of the form local@domain.tld.
+func Email(field string, value any) *ValidationError {
+	s, ok := value.(string)
+	if !ok {
+		return typeError(field, value, "a string")
+	}
+	if _, _, ok := SplitEmail(s); !ok {
+		return &ValidationError{Field: field, Message: field + " format is invalid", Code: CodeRuleEmail, Value: value}
+	}
+	return nil
+}
+
+// SplitEmail splits an address on its last "@" and reports whether it is
+// syntactically plausible: non-empty local part, a dotted domain and no
+// whitespace.
+func SplitEmail(email string) (local, domain string, ok bool) {
+	at := strings.LastIndex(email, "@")
+	if at <= 0 || at == len(email)-1 || strings.ContainsAny(email, " \t\r\n") {
+		return "", "", false
+	}
+	local, domain = email[:at], email[at+1:]
+	if dot := strings.Index(domain, "."); dot <= 0 || strings.HasSuffix(domain, ".") {
+		return "", "", false
+	}
+	return local, domain, true
+}
+
+// MatchesRegexp accepts strings matching pattern. The pattern is compiled
+// once; if it is invalid every check fails with the compile error as the
+// cause, so the mistake surfaces in tests rather than as a panic.
+func MatchesRegexp(pattern string) Rule {
+	re, compileErr := regexp.Compile(pattern)
+	return func(field string, value any) *ValidationError {
+		s, ok := value.(string)
+		if !ok {
+			return typeError(field, value, "a string")
+		}
+		if compileErr == nil && re.MatchString(s) {
+			return nil
+		}
+		return &ValidationError{
+			Field:       field,
+			Message:     fmt.Sprintf("%s does not match the required format", field),
+			Code:        CodeRulePattern,
+			Value:       value,
+			Constraints: map[string]any{"pattern": pattern},
+			Err:         compileErr,
+		}
+	}
+}
+
+// OneOf accepts values deeply equal to one of options.
+func OneOf(options ...any) Rule {
+	return func(field string, value any) *ValidationError {
+		for _, option := range options {
+			if reflect.DeepEqual(value, option) {
+				return nil
+			}
+		}
+		return &ValidationError{
+			Field:       field,
+			Message:     fmt.Sprintf("%s must be one of %v", field, options),
+			Code:        CodeRuleOneOf,
+			Value:       value,
+			Constraints: map[string]any{"oneOf": options},
+		}
+	}
+}
+
+func typeError(field string, value any, expected string) *ValidationError {
+	return &ValidationError{
+		Field:   field,
+		Message: fmt.Sprintf("%s must be %s", field, expected),
+		Code:    CodeRuleType,
+		Value:   value,
+	}
+}
@@ -0,0 +1,99 @@
+package custom
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		rule         Rule
+		value        any
+		expectedCode int
+	}{
+		{"required string present", Required, "a", 0},
+		{"required empty string", Required, "", CodeRuleRequired},
+		{"required nil", Required, nil, CodeRuleRequired},
+		{"required zero int", Required, 0, CodeRuleRequired},
+		{"range inside", Range(0, 130), 65, 0},
+		{"range inside int64", Range(0, 130), int64(130), 0},
+		{"range below", Range(0, 130), -1, CodeRuleMin},
+		{"range above", Range(0, 130), 131, CodeRuleMax},
+		{"range wrong type", Range(0, 130), "65", CodeRuleType},
+		{"email valid", Email, "a@b.c", 0},
+		{"email invalid", Email, "not-an-email", CodeRuleEmail},
+		{"email wrong type", Email, 42, CodeRuleType},
+		{"regexp match", MatchesRegexp(`^\d{5}$`), "12345", 0},
+		{"regexp mismatch", MatchesRegexp(`^\d{5}$`), "1234", CodeRulePattern},
+		{"regexp wrong type", MatchesRegexp(`^\d{5}$`), 12345, CodeRuleType},
+		{"one of match", OneOf("admin", "user"), "user", 0},
+		{"one of mismatch", OneOf("admin", "user"), "root", CodeRuleOneOf},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule("Field", tt.value)
+			if tt.expectedCode == 0 {
+				if err != nil {
+					t.Errorf("rule returned unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("rule expected code %d but got no error", tt.expectedCode)
+			}
+			if err.Code != tt.expectedCode {
+				t.Errorf("rule code = %d; want %d", err.Code, tt.expectedCode)
+			}
+			if err.Field != "Field" || err.Value != tt.value {
+				t.Errorf("rule error = %+v; want field 'Field' and value %v", *err, tt.value)
+			}
+		})
+	}
+}
+
+func TestMatchesRegexp_InvalidPattern(t *testing.T) {
+	err := MatchesRegexp(`([`)("Zip", "12345")
+	if err == nil {
+		t.Fatal("invalid pattern expected error but got none")
+	}
+	if err.Err == nil {
+		t.Error("invalid pattern error should wrap the compile error")
+	}
+	if err.Constraints["pattern"] != `([` {
+		t.Errorf("Constraints = %v; want the pattern", err.Constraints)
+	}
+}
+
+func TestValidateBounds_DistinctCodes(t *testing.T) {
+	below := ValidateBounds("Age", -1, 0, 130, 2001, 2002)
+	above := ValidateBounds("Age", 131, 0, 130, 2001, 2002)
+
+	if below == nil || below.Code != 2001 || below.Message != "Age cannot be less than 0" {
+		t.Errorf("ValidateBounds(below) = %v; want code 2001", below)
+	}
+	if above == nil || above.Code != 2002 || above.Message != "Age cannot be greater than 130" {
+		t.Errorf("ValidateBounds(above) = %v; want code 2002", above)
+	}
+	if ValidateBounds("Age", 0, 0, 130, 2001, 2002) != nil {
+		t.Error("ValidateBounds(in range) should return nil")
+	}
+}
+
+func TestSplitEmail(t *testing.T) {
+	local, domain, ok := SplitEmail("ops@mail.example.com")
+	if !ok || local != "ops" || domain != "mail.example.com" {
+		t.Errorf("SplitEmail() = (%q, %q, %v); want (ops, mail.example.com, true)", local, domain, ok)
+	}
+	if _, _, ok := SplitEmail("user@localhost"); ok {
+		t.Error("SplitEmail should reject undotted domains")
+	}
+}
+
+func TestRules_IsCode(t *testing.T) {
+	var err error = Range(0, 10)("Count", 11)
+	if !errors.Is(err, Code(CodeRuleMax)) {
+		t.Error("rule errors should match their code with errors.Is")
+	}
+}
@@ -0,0 +1,14 @@
+package custom
+
+// ValueAs returns the offending value of the first ValidationError in err
+// whose Value holds a T, sparing callers a manual type assertion on
+// interface{}. It reports false when no such error exists.
+func ValueAs[T any](err error) (T, bool) {
+	for _, ve := range AllValidationErrors(err) {
+		if v, ok := ve.Value.(T); ok {
+			return v, true
+		}
+	}
+	var zero T
+	return zero, false
+}
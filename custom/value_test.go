@@ -0,0 +1,37 @@
+package custom
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestValueAs(t *testing.T) {
+	err := fmt.Errorf("signup: %w", ValidationErrors{
+		{Field: "Email", Code: 2003, Value: ""},
+		{Field: "Age", Code: 2001, Value: -5},
+	})
+
+	age, ok := ValueAs[int](err)
+	if !ok || age != -5 {
+		t.Errorf("ValueAs[int]() = (%v, %v); want (-5, true)", age, ok)
+	}
+
+	email, ok := ValueAs[string](err)
+	if !ok || email != "" {
+		t.Errorf("ValueAs[string]() = (%q, %v); want (\"\", true)", email, ok)
+	}
+
+	if price, ok := ValueAs[float64](err); ok {
+		t.Errorf("ValueAs[float64]() = (%v, true); want not found", price)
+	}
+}
+
+func TestValueAs_NoValidationError(t *testing.T) {
+	if v, ok := ValueAs[int](errors.New("plain")); ok || v != 0 {
+		t.Errorf("ValueAs[int](plain) = (%v, %v); want (0, false)", v, ok)
+	}
+	if _, ok := ValueAs[int](&ValidationError{Field: "data", Value: nil}); ok {
+		t.Error("ValueAs[int] should not match a nil Value")
+	}
+}
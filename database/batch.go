@@ -0,0 +1,99 @@
+package database
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RowError is the failure of one row in a batch.
+type RowError struct {
+	Index int
+	// Key is the row's primary key when known, otherwise nil.
+	Key any
+	Err error
+}
+
+func (e *RowError) Error() string {
+	if e.Key != nil {
+		return fmt.Sprintf("row %d (key %v): %v", e.Index, e.Key, e.Err)
+	}
+	return fmt.Sprintf("row %d: %v", e.Index, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError reports the rows of a bulk INSERT or UPDATE that failed while
+// the others went through. It unwraps to one *RowError per failed row, so
+// errors.Is and errors.As see every row failure.
+type BatchError struct {
+	Operation string
+	Table     string
+	Total     int
+	Rows      []*RowError
+}
+
+// NewBatchError starts an empty report for a batch of total rows.
+func NewBatchError(op, table string, total int) *BatchError {
+	return &BatchError{Operation: op, Table: table, Total: total}
+}
+
+// Add records err for the row at index; nil errors are ignored.
+func (e *BatchError) Add(index int, key any, err error) {
+	if err != nil {
+		e.Rows = append(e.Rows, &RowError{Index: index, Key: key, Err: err})
+	}
+}
+
+// Err returns e when any row failed and nil otherwise, so callers can
+// return it directly.
+func (e *BatchError) Err() error {
+	if len(e.Rows) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Failed returns the indexes of failed rows in ascending order.
+func (e *BatchError) Failed() []int {
+	failed := make([]int, 0, len(e.Rows))
+	for _, row := range e.Rows {
+		failed = append(failed, row.Index)
+	}
+	sort.Ints(failed)
+	return failed
+}
+
+// Succeeded returns the indexes in [0, Total) that did not fail.
+func (e *BatchError) Succeeded() []int {
+	failed := make(map[int]bool, len(e.Rows))
+	for _, row := range e.Rows {
+		failed[row.Index] = true
+	}
+	succeeded := make([]int, 0, max(e.Total-len(failed), 0))
+	for i := 0; i < e.Total; i++ {
+		if !failed[i] {
+			succeeded = append(succeeded, i)
+		}
+	}
+	return succeeded
+}
+
+func (e *BatchError) Error() string {
+	parts := make([]string, len(e.Rows))
+	for i, row := range e.Rows {
+		parts[i] = row.Error()
+	}
+	return fmt.Sprintf("batch %s on %s: %d of %d rows failed: %s",
+		e.Operation, e.Table, len(e.Rows), e.Total, strings.Join(parts, "; "))
+}
+
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Rows))
+	for i, row := range e.Rows {
+		errs[i] = row
+	}
+	return errs
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestBatchError(t *testing.T) {
+	duplicate := &DatabaseError{Operation: "INSERT", Table: "users", Kind: KindConstraintViolation, Err: errors.New("duplicate key")}
+	tooLong := errors.New("value too long for column email")
+
+	batch := NewBatchError("INSERT", "users", 5)
+	batch.Add(3, "u-3", duplicate)
+	batch.Add(0, nil, nil)
+	batch.Add(1, nil, tooLong)
+
+	err := batch.Err()
+	if err == nil {
+		t.Fatal("Err() = nil; want the batch error")
+	}
+	if got := batch.Failed(); !reflect.DeepEqual(got, []int{1, 3}) {
+		t.Errorf("Failed() = %v; want [1 3]", got)
+	}
+	if got := batch.Succeeded(); !reflect.DeepEqual(got, []int{0, 2, 4}) {
+		t.Errorf("Succeeded() = %v; want [0 2 4]", got)
+	}
+
+	if !errors.Is(err, tooLong) || !IsConstraint(err) {
+		t.Error("BatchError should expose every row failure to errors.Is and errors.As")
+	}
+	var row *RowError
+	if !errors.As(err, &row) || row.Index != 3 || row.Key != "u-3" {
+		t.Errorf("errors.As(*RowError) = %+v; want the first failed row", row)
+	}
+
+	expected := "batch INSERT on users: 2 of 5 rows failed: row 3 (key u-3): " + duplicate.Error() + "; row 1: value too long for column email"
+	if err.Error() != expected {
+		t.Errorf("Error() = %q\nwant %q", err.Error(), expected)
+	}
+}
+
+func TestBatchError_NoFailures(t *testing.T) {
+	batch := NewBatchError("UPDATE", "orders", 3)
+	if err := batch.Err(); err != nil {
+		t.Errorf("Err() = %v; want nil", err)
+	}
+	if got := batch.Succeeded(); !reflect.DeepEqual(got, []int{0, 1, 2}) {
+		t.Errorf("Succeeded() = %v; want every row", got)
+	}
+	if got := batch.Failed(); len(got) != 0 {
+		t.Errorf("Failed() = %v; want none", got)
+	}
+}
@@ -0,0 +1,127 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, wrapped with the remaining cooldown, when a
+// Breaker rejects a call without running it.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// BreakerState is the state of a Breaker.
+type BreakerState int
+
+const (
+	// BreakerClosed lets every call through.
+	BreakerClosed BreakerState = iota
+	// BreakerOpen rejects every call until the cooldown passes.
+	BreakerOpen
+	// BreakerHalfOpen lets one probe call through to test the backend.
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerClosed:
+		return "closed"
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	}
+	return fmt.Sprintf("BreakerState(%d)", int(s))
+}
+
+// Breaker stops calling a backend that keeps failing. After Threshold
+// consecutive retryable failures it opens and rejects calls with
+// ErrCircuitOpen; once Cooldown has passed it lets a single probe through
+// and closes again if the probe succeeds. Non-retryable errors say nothing
+// about backend health and do not count as failures.
+type Breaker struct {
+	Threshold int
+	Cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewBreaker returns a closed breaker.
+func NewBreaker(threshold int, cooldown time.Duration) *Breaker {
+	return &Breaker{Threshold: threshold, Cooldown: cooldown}
+}
+
+// State returns the current state, moving from open to half-open once the
+// cooldown has passed.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+	return b.state
+}
+
+// Do runs op if the breaker allows it and records the outcome. If op
+// panics, nothing is recorded but a half-open probe is released, so the
+// next call can probe again instead of finding the breaker stuck.
+func (b *Breaker) Do(op func() error) error {
+	if err := b.allow(); err != nil {
+		return err
+	}
+	recorded := false
+	defer func() {
+		if !recorded {
+			b.mu.Lock()
+			b.probing = false
+			b.mu.Unlock()
+		}
+	}()
+	err := op()
+	b.record(err)
+	recorded = true
+	return err
+}
+
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.advanceLocked()
+	switch b.state {
+	case BreakerOpen:
+		remaining := b.Cooldown - clock.Now().Sub(b.openedAt)
+		return fmt.Errorf("%w: retry in %v", ErrCircuitOpen, remaining)
+	case BreakerHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%w: probe in progress", ErrCircuitOpen)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+	if !IsRetryable(err) {
+		// Success, or a failure that is the caller's fault, not the backend's
+		b.state = BreakerClosed
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.Threshold {
+		b.state = BreakerOpen
+		b.openedAt = clock.Now()
+	}
+}
+
+func (b *Breaker) advanceLocked() {
+	if b.state == BreakerOpen && clock.Now().Sub(b.openedAt) >= b.Cooldown {
+		b.state = BreakerHalfOpen
+	}
+}
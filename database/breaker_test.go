@@ -0,0 +1,139 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func timeoutErr() error {
+	return &DatabaseError{Operation: "SELECT", Table: "users", Kind: KindTimeout, Err: errors.New("i/o timeout"), Retryable: true}
+}
+
+func TestBreaker_OpensAfterThreshold(t *testing.T) {
+	defer SetClock(newFakeClock())()
+	b := NewBreaker(3, time.Minute)
+
+	for i := 0; i < 3; i++ {
+		if b.State() != BreakerClosed {
+			t.Fatalf("State() after %d failures = %v; want closed", i, b.State())
+		}
+		_ = b.Do(timeoutErr)
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("State() = %v; want open", b.State())
+	}
+
+	called := false
+	err := b.Do(func() error { called = true; return nil })
+	if !errors.Is(err, ErrCircuitOpen) || called {
+		t.Errorf("Do() on open breaker = %v, called = %v; want ErrCircuitOpen without calling op", err, called)
+	}
+}
+
+func TestBreaker_HalfOpenProbe(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+	b := NewBreaker(1, time.Minute)
+
+	_ = b.Do(timeoutErr)
+	fake.now = fake.now.Add(time.Minute)
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("State() after cooldown = %v; want half-open", b.State())
+	}
+
+	// A failed probe reopens the breaker for another cooldown
+	_ = b.Do(timeoutErr)
+	if b.State() != BreakerOpen {
+		t.Fatalf("State() after failed probe = %v; want open", b.State())
+	}
+
+	fake.now = fake.now.Add(time.Minute)
+	if err := b.Do(func() error { return nil }); err != nil {
+		t.Fatalf("Do() probe = %v; want success", err)
+	}
+	if b.State() != BreakerClosed {
+		t.Errorf("State() after successful probe = %v; want closed", b.State())
+	}
+}
+
+func TestBreaker_OneProbeAtATime(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+	b := NewBreaker(1, time.Second)
+	_ = b.Do(timeoutErr)
+	fake.now = fake.now.Add(time.Second)
+
+	err := b.Do(func() error {
+		if inner := b.Do(func() error { return nil }); !errors.Is(inner, ErrCircuitOpen) {
+			t.Errorf("concurrent Do() during probe = %v; want ErrCircuitOpen", inner)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("probe Do() = %v; want nil", err)
+	}
+}
+
+func TestBreaker_PanickingProbe(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+	b := NewBreaker(1, time.Second)
+	_ = b.Do(timeoutErr)
+	fake.now = fake.now.Add(time.Second)
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Error("Do() should let op's panic through")
+			}
+		}()
+		_ = b.Do(func() error { panic("driver bug") })
+	}()
+
+	if err := b.Do(func() error { return nil }); err != nil {
+		t.Fatalf("Do() after panicking probe = %v; want a new probe to run", err)
+	}
+	if b.State() != BreakerClosed {
+		t.Errorf("State() = %v; want closed after the new probe succeeds", b.State())
+	}
+}
+
+func TestBreaker_IgnoresNonRetryableErrors(t *testing.T) {
+	defer SetClock(newFakeClock())()
+	b := NewBreaker(2, time.Minute)
+	permanent := &DatabaseError{Operation: "INSERT", Table: "users", Kind: KindConstraintViolation, Err: errors.New("duplicate key")}
+
+	_ = b.Do(timeoutErr)
+	_ = b.Do(func() error { return permanent })
+	_ = b.Do(timeoutErr)
+
+	if b.State() != BreakerClosed {
+		t.Errorf("State() = %v; want closed since the failures were not consecutive", b.State())
+	}
+}
+
+func TestRetry_StopsWhenBreakerOpens(t *testing.T) {
+	defer SetClock(newFakeClock())()
+
+	calls := 0
+	policy := RetryPolicy{MaxAttempts: 10, Breaker: NewBreaker(2, time.Hour)}
+	err := Retry(context.Background(), policy, func() error {
+		calls++
+		return timeoutErr()
+	})
+
+	if !errors.Is(err, ErrCircuitOpen) {
+		t.Fatalf("Retry() = %v; want ErrCircuitOpen", err)
+	}
+	if calls != 2 {
+		t.Errorf("Retry() called op %d times; want 2 before the breaker opened", calls)
+	}
+}
+
+func TestBreakerState_String(t *testing.T) {
+	if BreakerHalfOpen.String() != "half-open" || BreakerState(9).String() != "BreakerState(9)" {
+		t.Errorf("String() = %q, %q", BreakerHalfOpen, BreakerState(9))
+	}
+}
@@ -0,0 +1,27 @@
+package database
+
+import "go-error-handling/wrapping"
+
+// Chain returns err followed by every error beneath it, depth-first, with
+// the children of errors implementing Unwrap() []error (such as
+// errors.Join) visited left to right. It returns nil for nil. Like
+// wrapping.Walk, it stops at wrapping.MaxChainDepth or at an error that
+// wraps itself, returning what it collected up to that point.
+func Chain(err error) []error {
+	return wrapping.Flatten(err)
+}
+
+// Root returns the innermost cause of err: the first error that wraps
+// nothing. Where an error has several children, Root follows the first,
+// matching the order errors.Is and errors.As search in. For a chain with
+// no such error, because it wraps itself or nests deeper than
+// wrapping.MaxChainDepth, Root returns the last error it reached.
+func Root(err error) error {
+	if leaves := wrapping.Leaves(err); len(leaves) > 0 {
+		return leaves[0]
+	}
+	if chain := Chain(err); len(chain) > 0 {
+		return chain[len(chain)-1]
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/internal/errtest"
+	"testing"
+)
+
+func TestChain(t *testing.T) {
+	root := errors.New("connection reset")
+	rollback := errors.New("rollback failed")
+	dbErr := &DatabaseError{Operation: "UPDATE", Table: "accounts", Err: fmt.Errorf("exec: %w", root)}
+	joined := errors.Join(dbErr, rollback)
+	top := fmt.Errorf("transfer: %w", joined)
+
+	chain := Chain(top)
+	expected := []error{top, joined, dbErr, dbErr.Err, root, rollback}
+	if len(chain) != len(expected) {
+		t.Fatalf("Chain() returned %d errors; want %d: %v", len(chain), len(expected), chain)
+	}
+	for i := range expected {
+		if chain[i] != expected[i] {
+			t.Errorf("Chain()[%d] = %v; want %v", i, chain[i], expected[i])
+		}
+	}
+
+	if Chain(nil) != nil {
+		t.Error("Chain(nil) should be nil")
+	}
+}
+
+func TestRoot(t *testing.T) {
+	root := errors.New("connection reset")
+	dbErr := &DatabaseError{Operation: "SELECT", Table: "users", Err: root}
+	noCause := &DatabaseError{Operation: "SELECT", Table: "users"}
+
+	tests := []struct {
+		name     string
+		err      error
+		expected error
+	}{
+		{"nil", nil, nil},
+		{"unwrapped", root, root},
+		{"single chain", fmt.Errorf("load: %w", dbErr), root},
+		{"joined follows first child", errors.Join(fmt.Errorf("a: %w", root), errors.New("b")), root},
+		{"error wrapping nil", noCause, noCause},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Root(tt.err); got != tt.expected {
+				t.Errorf("Root() = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChain_Cycle(t *testing.T) {
+	loop := &errtest.LoopError{}
+	wrapped := fmt.Errorf("retry: %w", loop)
+	loop.Next = wrapped
+
+	if chain := Chain(loop); len(chain) != 2 {
+		t.Errorf("Chain(cycle) returned %d errors; want 2: %v", len(chain), chain)
+	}
+	if got := Root(loop); got != wrapped {
+		t.Errorf("Root(cycle) = %v; want the last error before the cycle", got)
+	}
+}
@@ -0,0 +1,163 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Kind classifies why a database operation failed, so callers can branch on
+// the class of failure instead of parsing driver messages.
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindNotFound
+	KindConstraintViolation
+	KindDeadlock
+	KindConnection
+	KindTimeout
+	KindPermissionDenied
+	KindPoolExhausted
+	// KindCanceled means the caller gave up: its context was canceled.
+	// Retrying is pointless because the same context is already done.
+	KindCanceled
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindNotFound:
+		return "not found"
+	case KindConstraintViolation:
+		return "constraint violation"
+	case KindDeadlock:
+		return "deadlock"
+	case KindConnection:
+		return "connection"
+	case KindTimeout:
+		return "timeout"
+	case KindPermissionDenied:
+		return "permission denied"
+	case KindPoolExhausted:
+		return "pool exhausted"
+	case KindCanceled:
+		return "canceled"
+	}
+	return "unknown"
+}
+
+// Retryable reports whether failures of this kind are usually transient.
+func (k Kind) Retryable() bool {
+	switch k {
+	case KindDeadlock, KindConnection, KindTimeout, KindPoolExhausted:
+		return true
+	}
+	return false
+}
+
+// RetryAfterByKind is the RetryAfter hint Classify and FromSQLState attach
+// to retryable errors. Deadlocks and serialization failures clear as soon
+// as the competing transaction finishes, while timeouts and dropped
+// connections usually mean the server needs longer to recover.
+var RetryAfterByKind = map[Kind]time.Duration{
+	KindDeadlock:      20 * time.Millisecond,
+	KindPoolExhausted: 250 * time.Millisecond,
+	KindConnection:    500 * time.Millisecond,
+	KindTimeout:       time.Second,
+}
+
+// kindPatterns recognizes driver errors that carry no sentinel, by
+// lowercase message substring. Order matters: the first match wins.
+var kindPatterns = []struct {
+	kind     Kind
+	patterns []string
+}{
+	{KindConstraintViolation, []string{
+		"duplicate key", "duplicate entry", "unique constraint", "unique violation",
+		"foreign key constraint", "violates check constraint", "violates not-null constraint",
+	}},
+	{KindPermissionDenied, []string{"permission denied", "access denied"}},
+	{KindDeadlock, []string{"deadlock"}},
+	{KindConnection, []string{"connection refused", "connection reset", "broken pipe", "bad connection"}},
+	{KindTimeout, []string{"timeout", "timed out"}},
+}
+
+// Classify wraps a database/sql or driver error in a DatabaseError with
+// Kind, Retryable and RetryAfter filled in, so callers stop hand-building
+// the struct. Duplicate keys, foreign key violations and deadlocks come
+// back as UniqueConstraintError, ForeignKeyError and DeadlockError. It
+// returns nil for nil and returns err unchanged when it already carries a
+// DatabaseError.
+func Classify(err error, op, table string) error {
+	if err == nil {
+		return nil
+	}
+	if IsDatabaseError(err) {
+		return err
+	}
+	kind := classifyKind(err)
+	return specialize(NewError(op, table, err, WithKind(kind), WithRetryAfter(RetryAfterByKind[kind])))
+}
+
+func classifyKind(err error) Kind {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return KindNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return KindTimeout
+	case errors.Is(err, context.Canceled):
+		return KindCanceled
+	case errors.Is(err, sql.ErrConnDone), errors.Is(err, syscall.ECONNREFUSED), errors.Is(err, syscall.ECONNRESET):
+		return KindConnection
+	}
+	msg := strings.ToLower(err.Error())
+	for _, kp := range kindPatterns {
+		for _, pattern := range kp.patterns {
+			if strings.Contains(msg, pattern) {
+				return kp.kind
+			}
+		}
+	}
+	return KindUnknown
+}
+
+// KindOf returns the Kind of the DatabaseError in err's chain, or
+// KindUnknown when there is none.
+func KindOf(err error) Kind {
+	var dbErr *DatabaseError
+	if errors.As(err, &dbErr) {
+		return dbErr.Kind
+	}
+	return KindUnknown
+}
+
+// IsTimeout reports whether err carries a DatabaseError of KindTimeout.
+func IsTimeout(err error) bool {
+	return KindOf(err) == KindTimeout
+}
+
+// IsConstraint reports whether err carries a DatabaseError of
+// KindConstraintViolation, e.g. a duplicate key.
+func IsConstraint(err error) bool {
+	return KindOf(err) == KindConstraintViolation
+}
+
+// IsNotFound reports whether err carries a DatabaseError of KindNotFound.
+func IsNotFound(err error) bool {
+	return KindOf(err) == KindNotFound
+}
+
+// IsPermissionDenied reports whether err carries a DatabaseError of
+// KindPermissionDenied.
+func IsPermissionDenied(err error) bool {
+	return KindOf(err) == KindPermissionDenied
+}
+
+// IsConnection reports whether err carries a DatabaseError of
+// KindConnection.
+func IsConnection(err error) bool {
+	return KindOf(err) == KindConnection
+}
@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		expectedKind  Kind
+		expectedRetry bool
+	}{
+		{"no rows", sql.ErrNoRows, KindNotFound, false},
+		{"wrapped no rows", fmt.Errorf("scan user: %w", sql.ErrNoRows), KindNotFound, false},
+		{"postgres duplicate", errors.New(`pq: duplicate key value violates unique constraint "users_email_key"`), KindConstraintViolation, false},
+		{"mysql duplicate", errors.New("Error 1062: Duplicate entry 'a@b.io' for key 'email'"), KindConstraintViolation, false},
+		{"deadlock", errors.New("ERROR: deadlock detected (SQLSTATE 40P01)"), KindDeadlock, true},
+		{"connection refused", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}, KindConnection, true},
+		{"conn done", sql.ErrConnDone, KindConnection, true},
+		{"deadline", context.DeadlineExceeded, KindTimeout, true},
+		{"canceled", fmt.Errorf("query: %w", context.Canceled), KindCanceled, false},
+		{"timeout message", errors.New("i/o timeout"), KindTimeout, true},
+		{"foreign key", errors.New(`insert or update on table "orders" violates foreign key constraint "orders_user_id_fkey"`), KindConstraintViolation, false},
+		{"permission denied", errors.New("permission denied for table users"), KindPermissionDenied, false},
+		{"unknown", errors.New("disk quota exceeded"), KindUnknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Classify(tt.err, "SELECT", "users")
+
+			var dbErr *DatabaseError
+			if !errors.As(err, &dbErr) {
+				t.Fatalf("Classify() = %v; want a DatabaseError", err)
+			}
+			if dbErr.Kind != tt.expectedKind || dbErr.Retryable != tt.expectedRetry {
+				t.Errorf("Classify() kind = %v, retryable = %v; want %v, %v", dbErr.Kind, dbErr.Retryable, tt.expectedKind, tt.expectedRetry)
+			}
+			if dbErr.Operation != "SELECT" || dbErr.Table != "users" || dbErr.Timestamp.IsZero() {
+				t.Errorf("Classify() = %+v; want operation, table and timestamp populated", dbErr)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("Classify() should wrap the original error")
+			}
+		})
+	}
+}
+
+func TestClassify_PassThrough(t *testing.T) {
+	if err := Classify(nil, "SELECT", "users"); err != nil {
+		t.Errorf("Classify(nil) = %v; want nil", err)
+	}
+
+	existing := fmt.Errorf("repo: %w", &DatabaseError{Operation: "INSERT", Table: "orders", Kind: KindConstraintViolation})
+	if err := Classify(existing, "SELECT", "users"); err != existing {
+		t.Errorf("Classify(DatabaseError) = %v; want it unchanged", err)
+	}
+}
+
+func TestClassify_RetryAfter(t *testing.T) {
+	var dbErr *DatabaseError
+	if !errors.As(Classify(errors.New("deadlock detected"), "UPDATE", "accounts"), &dbErr) || dbErr.RetryAfter != 20*time.Millisecond {
+		t.Errorf("Classify(deadlock) RetryAfter = %v; want 20ms", dbErr.RetryAfter)
+	}
+	if !errors.As(Classify(context.DeadlineExceeded, "SELECT", "users"), &dbErr) || dbErr.RetryAfter != time.Second {
+		t.Errorf("Classify(deadline) RetryAfter = %v; want 1s", dbErr.RetryAfter)
+	}
+	if !errors.As(Classify(sql.ErrNoRows, "SELECT", "users"), &dbErr) || dbErr.RetryAfter != 0 {
+		t.Errorf("Classify(no rows) RetryAfter = %v; want 0", dbErr.RetryAfter)
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	if KindDeadlock.String() != "deadlock" || Kind(99).String() != "unknown" {
+		t.Errorf("String() = %q, %q; want deadlock, unknown", KindDeadlock, Kind(99))
+	}
+}
+
+func TestKindHelpers(t *testing.T) {
+	wrap := func(kind Kind) error {
+		return fmt.Errorf("repo: %w", &DatabaseError{Operation: "SELECT", Table: "users", Kind: kind})
+	}
+	helpers := map[Kind]func(error) bool{
+		KindTimeout:             IsTimeout,
+		KindConstraintViolation: IsConstraint,
+		KindNotFound:            IsNotFound,
+		KindPermissionDenied:    IsPermissionDenied,
+		KindConnection:          IsConnection,
+	}
+
+	for kind, is := range helpers {
+		for other := range helpers {
+			if got := is(wrap(other)); got != (kind == other) {
+				t.Errorf("helper for %v on %v = %v; want %v", kind, other, got, kind == other)
+			}
+		}
+		if is(errors.New(kind.String())) {
+			t.Errorf("helper for %v matched a plain error", kind)
+		}
+	}
+
+	if KindOf(nil) != KindUnknown {
+		t.Error("KindOf(nil) should be KindUnknown")
+	}
+}
@@ -0,0 +1,55 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+// Clock abstracts time for the retry helpers and for DatabaseError
+// timestamps, so tests can freeze or advance time instead of sleeping
+// through real backoff delays or ignoring timestamps.
+type Clock interface {
+	Now() time.Time
+	Sleep(ctx context.Context, d time.Duration) error
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) Sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+var clock Clock = realClock{}
+
+// SetClock replaces the package clock and returns a function restoring the
+// previous one, typically deferred in tests.
+func SetClock(c Clock) (restore func()) {
+	previous := clock
+	clock = c
+	return func() { clock = previous }
+}
+
+// FrozenClock is a Clock stuck at a fixed instant whose Sleep returns
+// immediately, for tests in other packages that compare timestamps.
+type FrozenClock struct {
+	At time.Time
+}
+
+func (c FrozenClock) Now() time.Time {
+	return c.At
+}
+
+func (c FrozenClock) Sleep(ctx context.Context, d time.Duration) error {
+	return ctx.Err()
+}
@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock advances instantly on Sleep so retry tests never block.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Sleep(ctx context.Context, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+	return nil
+}
+
+func TestSetClock_Restore(t *testing.T) {
+	fake := newFakeClock()
+	restore := SetClock(fake)
+
+	if clock != fake {
+		t.Error("SetClock should install the given clock")
+	}
+
+	restore()
+	if _, ok := clock.(realClock); !ok {
+		t.Errorf("restore should reinstate the real clock, got %T", clock)
+	}
+}
+
+func TestRealClock_SleepCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := (realClock{}).Sleep(ctx, time.Hour); err != context.Canceled {
+		t.Errorf("realClock.Sleep with canceled context = %v; want %v", err, context.Canceled)
+	}
+}
+
+func TestFrozenClock(t *testing.T) {
+	at := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	defer SetClock(FrozenClock{At: at})()
+
+	err := NewError("SELECT", "users", nil)
+	if !err.Timestamp.Equal(at) {
+		t.Errorf("Timestamp = %v; want %v", err.Timestamp, at)
+	}
+	if sleepErr := clock.Sleep(context.Background(), time.Hour); sleepErr != nil {
+		t.Errorf("Sleep() = %v; want nil", sleepErr)
+	}
+}
@@ -0,0 +1,51 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// WrapContext is Classify for errors that may stem from ctx ending. When err
+// is, or happened after, a cancellation or deadline, the result is a
+// DatabaseError of KindCanceled or KindTimeout whose chain includes the
+// context error, so errors.Is(err, context.DeadlineExceeded) and
+// errors.Is(err, context.Canceled) hold even if the driver reported something
+// else such as "bad connection". Other errors are classified as Classify
+// would. Trace IDs are taken from ctx and opts are applied last.
+// It returns nil for nil and returns err unchanged when it already carries
+// a DatabaseError.
+func WrapContext(ctx context.Context, err error, op, table string, opts ...Option) error {
+	if err == nil {
+		return nil
+	}
+	if IsDatabaseError(err) {
+		return err
+	}
+	ctxErr := contextCause(ctx, err)
+	if ctxErr == nil {
+		kind := classifyKind(err)
+		opts = append([]Option{WithKind(kind), WithRetryAfter(RetryAfterByKind[kind])}, opts...)
+		return specialize(NewErrorFromContext(ctx, op, table, err, opts...))
+	}
+	if !errors.Is(err, ctxErr) {
+		err = fmt.Errorf("%w: %w", err, ctxErr)
+	}
+	kind := KindTimeout
+	if errors.Is(ctxErr, context.Canceled) {
+		kind = KindCanceled
+	}
+	return NewErrorFromContext(ctx, op, table, err, append([]Option{WithKind(kind)}, opts...)...)
+}
+
+// contextCause returns the context error behind err: the one err already
+// wraps, or else ctx's own error if ctx has ended.
+func contextCause(ctx context.Context, err error) error {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return context.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return context.DeadlineExceeded
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,77 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestWrapContext(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Unix(0, 0))
+	defer cancelExpired()
+
+	tests := []struct {
+		name          string
+		ctx           context.Context
+		err           error
+		expectedKind  Kind
+		expectedCause error
+	}{
+		{"deadline error", context.Background(), fmt.Errorf("scan: %w", context.DeadlineExceeded), KindTimeout, context.DeadlineExceeded},
+		{"canceled error", context.Background(), context.Canceled, KindCanceled, context.Canceled},
+		{"driver error after deadline", expired, errors.New("driver: bad connection"), KindTimeout, context.DeadlineExceeded},
+		{"driver error after cancel", canceled, errors.New("driver: bad connection"), KindCanceled, context.Canceled},
+		{"live context", context.Background(), errors.New("connection refused"), KindConnection, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WrapContext(tt.ctx, tt.err, "SELECT", "users")
+
+			var dbErr *DatabaseError
+			if !errors.As(err, &dbErr) || dbErr.Operation != "SELECT" || dbErr.Table != "users" {
+				t.Fatalf("WrapContext() = %v; want a DatabaseError for SELECT on users", err)
+			}
+			if dbErr.Kind != tt.expectedKind {
+				t.Errorf("WrapContext() kind = %v; want %v", dbErr.Kind, tt.expectedKind)
+			}
+			if !errors.Is(err, tt.err) {
+				t.Error("WrapContext() should wrap the original error")
+			}
+			if tt.expectedCause != nil && !errors.Is(err, tt.expectedCause) {
+				t.Errorf("errors.Is(%v, %v) = false; want true", err, tt.expectedCause)
+			}
+		})
+	}
+}
+
+func TestWrapContext_CanceledIsNotRetried(t *testing.T) {
+	err := WrapContext(context.Background(), context.Canceled, "UPDATE", "accounts")
+	if IsRetryable(err) {
+		t.Errorf("IsRetryable(%v) = true; want a canceled operation left alone", err)
+	}
+}
+
+func TestWrapContext_TraceAndOptions(t *testing.T) {
+	ctx := ContextWithTrace(context.Background(), "trace-1", "span-1")
+	err := WrapContext(ctx, context.DeadlineExceeded, "SELECT", "users", WithQuery("SELECT 1"))
+
+	var dbErr *DatabaseError
+	if !errors.As(err, &dbErr) || dbErr.TraceID != "trace-1" || dbErr.Query != "SELECT 1" {
+		t.Errorf("WrapContext() = %+v; want trace IDs from ctx and the query option applied", dbErr)
+	}
+}
+
+func TestWrapContext_PassThrough(t *testing.T) {
+	if err := WrapContext(context.Background(), nil, "SELECT", "users"); err != nil {
+		t.Errorf("WrapContext(nil) = %v; want nil", err)
+	}
+	existing := &DatabaseError{Operation: "INSERT", Kind: KindConstraintViolation}
+	if err := WrapContext(context.Background(), existing, "SELECT", "users"); err != existing {
+		t.Errorf("WrapContext(DatabaseError) = %v; want it unchanged", err)
+	}
+}
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -12,13 +14,53 @@ type DatabaseError struct {
 	Err       error
 	Timestamp time.Time
 	Retryable bool
+	// RetryAfter is how long to wait before the next attempt; Retry uses it
+	// instead of its backoff. Classify and FromSQLState set it per Kind.
+	// Zero means no preference.
+	RetryAfter time.Duration
+	// Kind is set by Classify; hand-built errors default to KindUnknown.
+	Kind Kind
+	// RowsAffected counts rows the statement changed before failing.
+	RowsAffected int64
+	// TraceID and SpanID correlate the failure with a distributed trace;
+	// NewErrorFromContext fills them in.
+	TraceID string
+	SpanID  string
+	// SQLState is the five-character code reported by the driver, if any.
+	SQLState string
+	// Details holds extra diagnostics such as rows examined, host or shard
+	// ID. They appear in JSON and slog output but not in Error().
+	Details map[string]any
 }
 
 func (e *DatabaseError) Error() string {
-	return fmt.Sprintf("database error [%s on %s]: %v (retryable: %v, timestamp: %s)",
+	msg := fmt.Sprintf("database error [%s on %s]: %v (retryable: %v, timestamp: %s",
 		e.Operation, e.Table, e.Err, e.Retryable, e.Timestamp.Format(time.RFC3339))
+	if e.Query != "" {
+		msg += ", query: " + e.SafeQuery()
+	}
+	if e.SQLState != "" {
+		msg += ", sqlstate: " + e.SQLState
+	}
+	if e.TraceID != "" {
+		msg += ", trace: " + e.TraceID
+	}
+	if e.SpanID != "" {
+		msg += ", span: " + e.SpanID
+	}
+	return msg + ")"
+}
+
+// IsDatabaseError reports whether err's chain contains a DatabaseError.
+func IsDatabaseError(err error) bool {
+	var dbErr *DatabaseError
+	return errors.As(err, &dbErr)
 }
 
+// Unwramp returns the error err wraps, or nil.
+//
+// Deprecated: Unwramp ignores errors with multiple children. Use Chain to
+// walk every error in the tree or Root to find the original cause.
 func Unwramp(err error) error {
 	type unwrapper interface {
 		Unwrap() error
@@ -32,3 +74,47 @@ func Unwramp(err error) error {
 func (e *DatabaseError) Unwrap() error {
 	return e.Err
 }
+
+// SpanStatus maps the error onto OpenTelemetry span status conventions so
+// tracing code can record DB failures without inspecting the struct.
+func (e *DatabaseError) SpanStatus() (code string, description string) {
+	cause := ""
+	if e.Err != nil {
+		cause = e.Err.Error()
+	}
+	if e.Retryable {
+		return "ERROR", "retryable: " + cause
+	}
+	return "ERROR", cause
+}
+
+// SpanStatusOf finds a DatabaseError anywhere in err's chain and returns its
+// span status. Other errors get a generic ERROR status and nil reports OK.
+func SpanStatusOf(err error) (code string, description string) {
+	if err == nil {
+		return "OK", ""
+	}
+	var dbErr *DatabaseError
+	if errors.As(err, &dbErr) {
+		return dbErr.SpanStatus()
+	}
+	return "ERROR", err.Error()
+}
+
+// Timeout reports whether the failure was a timeout, matching the net.Error
+// convention. It is true for KindTimeout, for a context deadline and for
+// causes that report Timeout themselves.
+func (e *DatabaseError) Timeout() bool {
+	if e.Kind == KindTimeout || errors.Is(e.Err, context.DeadlineExceeded) {
+		return true
+	}
+	var timeout interface{ Timeout() bool }
+	return errors.As(e.Err, &timeout) && timeout.Timeout()
+}
+
+// Temporary reports whether retrying may succeed, matching the net.Error
+// convention. It follows IsRetryable, so NonRetryableCauses still win over
+// the Retryable flag.
+func (e *DatabaseError) Temporary() bool {
+	return IsRetryable(e)
+}
@@ -1,8 +1,11 @@
 package database
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"net"
+	"os"
 	"strings"
 	"testing"
 	"time"
@@ -175,3 +178,109 @@ func TestDatabaseError_AsError(t *testing.T) {
 		t.Errorf("Extracted DatabaseError.Operation = %v; want %v", target.Operation, "INSERT")
 	}
 }
+
+func TestDatabaseError_SpanStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		retryable    bool
+		expectedDesc string
+	}{
+		{"retryable error", true, "retryable: connection timeout"},
+		{"non-retryable error", false, "connection timeout"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbErr := &DatabaseError{
+				Operation: "SELECT",
+				Table:     "users",
+				Err:       errors.New("connection timeout"),
+				Timestamp: time.Now(),
+				Retryable: tt.retryable,
+			}
+
+			code, desc := dbErr.SpanStatus()
+			if code != "ERROR" {
+				t.Errorf("SpanStatus() code = %v; want ERROR", code)
+			}
+			if desc != tt.expectedDesc {
+				t.Errorf("SpanStatus() description = %v; want %v", desc, tt.expectedDesc)
+			}
+
+			// The chain helper should find the same status through a wrapper
+			wrapped := fmt.Errorf("query failed: %w", dbErr)
+			chainCode, chainDesc := SpanStatusOf(wrapped)
+			if chainCode != code || chainDesc != desc {
+				t.Errorf("SpanStatusOf(wrapped) = (%v, %v); want (%v, %v)", chainCode, chainDesc, code, desc)
+			}
+		})
+	}
+}
+
+func TestSpanStatusOf_NonDatabaseError(t *testing.T) {
+	code, desc := SpanStatusOf(errors.New("something broke"))
+	if code != "ERROR" {
+		t.Errorf("SpanStatusOf(non-db) code = %v; want ERROR", code)
+	}
+	if desc != "something broke" {
+		t.Errorf("SpanStatusOf(non-db) description = %v; want %v", desc, "something broke")
+	}
+
+	code, desc = SpanStatusOf(nil)
+	if code != "OK" || desc != "" {
+		t.Errorf("SpanStatusOf(nil) = (%v, %v); want (OK, \"\")", code, desc)
+	}
+}
+
+func TestIsDatabaseError(t *testing.T) {
+	dbErr := &DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("test"), Timestamp: time.Now()}
+
+	if !IsDatabaseError(fmt.Errorf("wrapped: %w", dbErr)) {
+		t.Error("IsDatabaseError should find a wrapped DatabaseError")
+	}
+	if IsDatabaseError(errors.New("plain")) {
+		t.Error("IsDatabaseError(plain) should be false")
+	}
+	if IsDatabaseError(nil) {
+		t.Error("IsDatabaseError(nil) should be false")
+	}
+}
+
+func TestDatabaseError_TimeoutTemporary(t *testing.T) {
+	tests := []struct {
+		name              string
+		err               *DatabaseError
+		expectedTimeout   bool
+		expectedTemporary bool
+	}{
+		{"timeout kind", &DatabaseError{Kind: KindTimeout, Err: errors.New("slow"), Retryable: true}, true, true},
+		{"context deadline", &DatabaseError{Err: fmt.Errorf("query: %w", context.DeadlineExceeded)}, true, false},
+		{"net timeout cause", &DatabaseError{Err: &net.OpError{Op: "read", Err: os.ErrDeadlineExceeded}, Retryable: true}, true, true},
+		{"retryable connection", &DatabaseError{Kind: KindConnection, Err: errors.New("connection refused"), Retryable: true}, false, true},
+		{"non-retryable cause", &DatabaseError{Err: errors.New("syntax error at FROM"), Retryable: true}, false, false},
+		{"permanent", &DatabaseError{Kind: KindConstraintViolation, Err: errors.New("duplicate key")}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Timeout(); got != tt.expectedTimeout {
+				t.Errorf("Timeout() = %v; want %v", got, tt.expectedTimeout)
+			}
+			if got := tt.err.Temporary(); got != tt.expectedTemporary {
+				t.Errorf("Temporary() = %v; want %v", got, tt.expectedTemporary)
+			}
+		})
+	}
+}
+
+func TestDatabaseError_NetErrorCompatible(t *testing.T) {
+	err := fmt.Errorf("load user: %w", &DatabaseError{Kind: KindTimeout, Err: errors.New("i/o timeout"), Retryable: true})
+
+	var netErr net.Error
+	if !errors.As(err, &netErr) {
+		t.Fatal("DatabaseError should satisfy net.Error")
+	}
+	if !netErr.Timeout() {
+		t.Error("net.Error.Timeout() = false; want true")
+	}
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// DeadLetterEntry is an operation parked because it failed permanently.
+type DeadLetterEntry struct {
+	Operation string
+	Table     string
+	// Query is kept raw so operators can replay it; treat entries as
+	// sensitive.
+	Query     string
+	Err       error
+	Timestamp time.Time
+}
+
+// DeadLetter stores poison operations so a pipeline can move on and
+// operators can inspect or replay them later.
+type DeadLetter interface {
+	Park(entry DeadLetterEntry)
+	// Drain returns every parked entry in arrival order and empties the
+	// store.
+	Drain() []DeadLetterEntry
+}
+
+// MemoryDeadLetter is an in-process DeadLetter, safe for concurrent use.
+type MemoryDeadLetter struct {
+	mu      sync.Mutex
+	entries []DeadLetterEntry
+}
+
+func NewMemoryDeadLetter() *MemoryDeadLetter {
+	return &MemoryDeadLetter{}
+}
+
+func (d *MemoryDeadLetter) Park(entry DeadLetterEntry) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.entries = append(d.entries, entry)
+}
+
+func (d *MemoryDeadLetter) Drain() []DeadLetterEntry {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	entries := d.entries
+	d.entries = nil
+	return entries
+}
+
+// ParkIfPermanent parks err in dl when it carries a DatabaseError that is
+// not retryable, and reports whether it did. Retryable and non-database
+// errors are left to the caller.
+func ParkIfPermanent(dl DeadLetter, err error) bool {
+	var dbErr *DatabaseError
+	if !errors.As(err, &dbErr) || IsRetryable(err) {
+		return false
+	}
+	dl.Park(DeadLetterEntry{
+		Operation: dbErr.Operation,
+		Table:     dbErr.Table,
+		Query:     dbErr.Query,
+		Err:       err,
+		Timestamp: clock.Now(),
+	})
+	return true
+}
@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParkIfPermanent(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+	dl := NewMemoryDeadLetter()
+
+	permanent := fmt.Errorf("import row 7: %w", &DatabaseError{
+		Operation: "INSERT",
+		Table:     "users",
+		Query:     "INSERT INTO users (email) VALUES ('a@b.io')",
+		Kind:      KindConstraintViolation,
+		Err:       errors.New("duplicate key"),
+	})
+
+	if !ParkIfPermanent(dl, permanent) {
+		t.Fatal("ParkIfPermanent() = false; want the permanent failure parked")
+	}
+	if ParkIfPermanent(dl, timeoutErr()) {
+		t.Error("ParkIfPermanent() parked a retryable error")
+	}
+	if ParkIfPermanent(dl, errors.New("plain")) || ParkIfPermanent(dl, nil) {
+		t.Error("ParkIfPermanent() parked an error without a DatabaseError")
+	}
+
+	entries := dl.Drain()
+	if len(entries) != 1 {
+		t.Fatalf("Drain() returned %d entries; want 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Operation != "INSERT" || entry.Table != "users" || entry.Err != permanent || !entry.Timestamp.Equal(fake.now) {
+		t.Errorf("entry = %+v; want the parked operation", entry)
+	}
+	if entry.Query != "INSERT INTO users (email) VALUES ('a@b.io')" {
+		t.Errorf("entry.Query = %q; want the raw query for replay", entry.Query)
+	}
+
+	if again := dl.Drain(); len(again) != 0 {
+		t.Errorf("second Drain() = %v; want empty", again)
+	}
+}
+
+func TestRetry_ParksPermanentFailures(t *testing.T) {
+	defer SetClock(newFakeClock())()
+	dl := NewMemoryDeadLetter()
+	policy := RetryPolicy{MaxAttempts: 3, DeadLetter: dl}
+
+	permanent := &DatabaseError{Operation: "INSERT", Table: "orders", Err: errors.New("duplicate key")}
+	if err := Retry(context.Background(), policy, func() error { return permanent }); err != permanent {
+		t.Fatalf("Retry() = %v; want the permanent error", err)
+	}
+	_ = Retry(context.Background(), policy, timeoutErr)
+
+	entries := dl.Drain()
+	if len(entries) != 1 || entries[0].Err != permanent {
+		t.Errorf("Drain() = %v; want only the permanent failure", entries)
+	}
+}
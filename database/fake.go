@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// injectedCauses are the driver-style messages Fake fails with, chosen so
+// Classify would assign the same Kind to them.
+var injectedCauses = map[Kind]error{
+	KindNotFound:            sql.ErrNoRows,
+	KindConstraintViolation: errors.New("duplicate key value violates unique constraint"),
+	KindDeadlock:            errors.New("deadlock detected"),
+	KindConnection:          errors.New("connection refused"),
+	KindTimeout:             errors.New("connection timeout"),
+	KindPermissionDenied:    errors.New("permission denied"),
+	KindPoolExhausted:       ErrPoolExhausted,
+}
+
+// Fake is an in-memory table store with fault injection, for examples and
+// tests that need success paths and intermittent failures without a real
+// database. Configure it before use; all methods are safe for concurrent
+// use.
+type Fake struct {
+	mu            sync.Mutex
+	rows          map[string][]any
+	calls         int
+	failEvery     int
+	failEveryKind Kind
+	failTables    map[string]Kind
+	latency       time.Duration
+}
+
+func NewFake() *Fake {
+	return &Fake{rows: make(map[string][]any), failTables: make(map[string]Kind)}
+}
+
+// Insert appends rows to table.
+func (f *Fake) Insert(table string, rows ...any) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.rows[table] = append(f.rows[table], rows...)
+	return f
+}
+
+// FailEvery makes every nth Query fail with kind, regardless of table.
+// n <= 0 disables it.
+func (f *Fake) FailEvery(n int, kind Kind) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failEvery, f.failEveryKind = n, kind
+	return f
+}
+
+// FailTable makes every Query on table fail with kind.
+func (f *Fake) FailTable(table string, kind Kind) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failTables[table] = kind
+	return f
+}
+
+// WithLatency delays every Query by d using the package clock.
+func (f *Fake) WithLatency(d time.Duration) *Fake {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.latency = d
+	return f
+}
+
+// Calls returns how many times Query has been called.
+func (f *Fake) Calls() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.calls
+}
+
+// Query returns up to limit rows of table, or an injected DatabaseError
+// carrying the trace IDs in ctx. A limit <= 0 returns every row.
+func (f *Fake) Query(ctx context.Context, table string, limit int) ([]any, error) {
+	f.mu.Lock()
+	f.calls++
+	call, latency := f.calls, f.latency
+	kind, fail := f.failTables[table]
+	if !fail && f.failEvery > 0 && call%f.failEvery == 0 {
+		kind, fail = f.failEveryKind, true
+	}
+	rows := f.rows[table]
+	if limit > 0 && len(rows) > limit {
+		rows = rows[:limit]
+	}
+	rows = append([]any(nil), rows...)
+	f.mu.Unlock()
+
+	query := fmt.Sprintf("SELECT * FROM %s LIMIT %d", table, limit)
+	if latency > 0 {
+		if err := clock.Sleep(ctx, latency); err != nil {
+			return nil, WrapContext(ctx, err, "SELECT", table, WithQuery(query))
+		}
+	}
+	if fail {
+		cause, ok := injectedCauses[kind]
+		if !ok {
+			cause = fmt.Errorf("injected %s failure", kind)
+		}
+		return nil, specialize(NewErrorFromContext(ctx, "SELECT", table, cause, WithQuery(query), WithKind(kind)))
+	}
+	return rows, nil
+}
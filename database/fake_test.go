@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFake_QueryRows(t *testing.T) {
+	f := NewFake().Insert("users", "ana", "li", "sam")
+
+	rows, err := f.Query(context.Background(), "users", 2)
+	if err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(rows) != 2 || rows[0] != "ana" || rows[1] != "li" {
+		t.Errorf("Query() = %v; want the first two rows", rows)
+	}
+
+	if rows, _ := f.Query(context.Background(), "users", 0); len(rows) != 3 {
+		t.Errorf("Query(limit 0) returned %d rows; want all 3", len(rows))
+	}
+	if rows, err := f.Query(context.Background(), "orders", 10); err != nil || len(rows) != 0 {
+		t.Errorf("Query(empty table) = %v, %v; want no rows", rows, err)
+	}
+}
+
+func TestFake_FailEvery(t *testing.T) {
+	f := NewFake().Insert("users", "ana").FailEvery(3, KindDeadlock)
+
+	var failures []int
+	for call := 1; call <= 6; call++ {
+		if _, err := f.Query(context.Background(), "users", 10); err != nil {
+			var deadlock *DeadlockError
+			if !errors.As(err, &deadlock) || !IsRetryable(err) {
+				t.Errorf("call %d error = %v; want a retryable DeadlockError", call, err)
+			}
+			failures = append(failures, call)
+		}
+	}
+	if len(failures) != 2 || failures[0] != 3 || failures[1] != 6 {
+		t.Errorf("failed calls = %v; want [3 6]", failures)
+	}
+	if f.Calls() != 6 {
+		t.Errorf("Calls() = %d; want 6", f.Calls())
+	}
+}
+
+func TestFake_FailTable(t *testing.T) {
+	f := NewFake().FailTable("audit", KindPermissionDenied).FailTable("users", KindNotFound)
+	ctx := ContextWithTrace(context.Background(), "trace-9", "span-1")
+
+	_, err := f.Query(ctx, "audit", 5)
+	var dbErr *DatabaseError
+	if !errors.As(err, &dbErr) {
+		t.Fatalf("Query() = %v; want DatabaseError", err)
+	}
+	if dbErr.Kind != KindPermissionDenied || dbErr.Retryable || dbErr.TraceID != "trace-9" {
+		t.Errorf("DatabaseError = %+v; want non-retryable permission denied with the trace ID", dbErr)
+	}
+	if dbErr.Query != "SELECT * FROM audit LIMIT 5" {
+		t.Errorf("Query = %q; want the simulated statement", dbErr.Query)
+	}
+
+	if _, err := f.Query(ctx, "users", 5); !errors.Is(err, sql.ErrNoRows) || !IsNotFound(err) {
+		t.Errorf("Query(users) = %v; want sql.ErrNoRows classified as not found", err)
+	}
+	if _, err := f.Query(ctx, "orders", 5); err != nil {
+		t.Errorf("Query(orders) = %v; want tables without faults to succeed", err)
+	}
+}
+
+func TestFake_Latency(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+
+	f := NewFake().WithLatency(250 * time.Millisecond)
+	if _, err := f.Query(context.Background(), "users", 1); err != nil {
+		t.Fatalf("Query() error = %v", err)
+	}
+	if len(fake.sleeps) != 1 || fake.sleeps[0] != 250*time.Millisecond {
+		t.Errorf("sleeps = %v; want one 250ms delay", fake.sleeps)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := f.Query(ctx, "users", 1); !errors.Is(err, context.Canceled) || KindOf(err) != KindCanceled {
+		t.Errorf("Query(canceled) = %v; want a canceled DatabaseError wrapping context.Canceled", err)
+	}
+}
@@ -0,0 +1,20 @@
+package database
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Fingerprint returns a stable 16-character hex digest of the operation,
+// table, Kind and the Go type of the root cause, so error sinks can group
+// repeats of the same failure. Timestamps, messages and query literals are
+// left out, so two failures that differ only in those share a fingerprint.
+func (e *DatabaseError) Fingerprint() string {
+	rootType := "<nil>"
+	if root := Root(e.Err); root != nil {
+		rootType = fmt.Sprintf("%T", root)
+	}
+	sum := sha256.Sum256([]byte(e.Operation + "\x00" + e.Table + "\x00" + e.Kind.String() + "\x00" + rootType))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestDatabaseError_Fingerprint(t *testing.T) {
+	base := &DatabaseError{Operation: "SELECT", Table: "users", Kind: KindTimeout, Err: errors.New("i/o timeout")}
+	fingerprint := base.Fingerprint()
+
+	if len(fingerprint) != 16 {
+		t.Fatalf("Fingerprint() = %q; want 16 hex characters", fingerprint)
+	}
+
+	same := []*DatabaseError{
+		{Operation: "SELECT", Table: "users", Kind: KindTimeout, Err: errors.New("read tcp: i/o timeout"), Timestamp: time.Now()},
+		{Operation: "SELECT", Table: "users", Kind: KindTimeout, Err: fmt.Errorf("query: %w", errors.New("timeout")), Query: "SELECT * FROM users WHERE id = 42"},
+	}
+	for _, err := range same {
+		if got := err.Fingerprint(); got != fingerprint {
+			t.Errorf("Fingerprint(%v) = %q; want %q", err, got, fingerprint)
+		}
+	}
+
+	different := []*DatabaseError{
+		{Operation: "UPDATE", Table: "users", Kind: KindTimeout, Err: errors.New("i/o timeout")},
+		{Operation: "SELECT", Table: "orders", Kind: KindTimeout, Err: errors.New("i/o timeout")},
+		{Operation: "SELECT", Table: "users", Kind: KindConnection, Err: errors.New("i/o timeout")},
+		{Operation: "SELECT", Table: "users", Kind: KindTimeout, Err: context.DeadlineExceeded},
+		{Operation: "SELECT", Table: "users", Kind: KindTimeout},
+	}
+	for _, err := range different {
+		if got := err.Fingerprint(); got == fingerprint {
+			t.Errorf("Fingerprint(%+v) = %q; want it to differ from %+v", err, got, base)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package database
+
+import (
+	"encoding/json"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+)
+
+// databaseErrorJSON is the wire schema. The query is always sanitized and
+// the cause is flattened into messages because error values cannot be
+// encoded.
+type databaseErrorJSON struct {
+	Operation    string         `json:"operation"`
+	Table        string         `json:"table"`
+	Kind         string         `json:"kind"`
+	Retryable    bool           `json:"retryable"`
+	Timestamp    time.Time      `json:"timestamp"`
+	Query        string         `json:"query,omitempty"`
+	RowsAffected int64          `json:"rows_affected,omitempty"`
+	TraceID      string         `json:"trace_id,omitempty"`
+	SpanID       string         `json:"span_id,omitempty"`
+	SQLState     string         `json:"sqlstate,omitempty"`
+	Details      map[string]any `json:"details,omitempty"`
+	Causes       []string       `json:"causes,omitempty"`
+	Fingerprint  string         `json:"fingerprint"`
+}
+
+func (e *DatabaseError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.jsonFields())
+}
+
+func (e *DatabaseError) jsonFields() databaseErrorJSON {
+	return databaseErrorJSON{
+		Operation:    e.Operation,
+		Table:        e.Table,
+		Kind:         e.Kind.String(),
+		Retryable:    e.Retryable,
+		Timestamp:    e.Timestamp,
+		Query:        e.SafeQuery(),
+		RowsAffected: e.RowsAffected,
+		TraceID:      e.TraceID,
+		SpanID:       e.SpanID,
+		SQLState:     e.SQLState,
+		Details:      e.Details,
+		Causes:       causeChain(e.Err),
+		Fingerprint:  e.Fingerprint(),
+	}
+}
+
+// LogValue renders the error as a group of structured fields for slog.
+func (e *DatabaseError) LogValue() slog.Value {
+	return slog.GroupValue(e.logAttrs()...)
+}
+
+func (e *DatabaseError) logAttrs() []slog.Attr {
+	attrs := []slog.Attr{
+		slog.String("operation", e.Operation),
+		slog.String("table", e.Table),
+		slog.String("kind", e.Kind.String()),
+		slog.Bool("retryable", e.Retryable),
+		slog.Time("timestamp", e.Timestamp),
+		slog.String("fingerprint", e.Fingerprint()),
+	}
+	if e.Query != "" {
+		attrs = append(attrs, slog.String("query", e.SafeQuery()))
+	}
+	if e.SQLState != "" {
+		attrs = append(attrs, slog.String("sqlstate", e.SQLState))
+	}
+	if e.TraceID != "" {
+		attrs = append(attrs, slog.String("trace_id", e.TraceID))
+	}
+	if e.SpanID != "" {
+		attrs = append(attrs, slog.String("span_id", e.SpanID))
+	}
+	if len(e.Details) > 0 {
+		details := make([]slog.Attr, 0, len(e.Details))
+		for _, key := range slices.Sorted(maps.Keys(e.Details)) {
+			details = append(details, slog.Any(key, e.Details[key]))
+		}
+		attrs = append(attrs, slog.Attr{Key: "details", Value: slog.GroupValue(details...)})
+	}
+	if causes := causeChain(e.Err); len(causes) > 0 {
+		attrs = append(attrs, slog.Any("causes", causes))
+	}
+	return attrs
+}
+
+// constraintErrorJSON is the wire schema of UniqueConstraintError and
+// ForeignKeyError: the DatabaseError fields plus the constraint.
+type constraintErrorJSON struct {
+	databaseErrorJSON
+	ConstraintName string `json:"constraint_name,omitempty"`
+	Column         string `json:"column,omitempty"`
+}
+
+func (e *UniqueConstraintError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(constraintErrorJSON{e.jsonFields(), e.ConstraintName, e.Column})
+}
+
+// LogValue adds the constraint to the DatabaseError fields.
+func (e *UniqueConstraintError) LogValue() slog.Value {
+	return slog.GroupValue(constraintAttrs(e.logAttrs(), e.ConstraintName, e.Column)...)
+}
+
+func (e *ForeignKeyError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(constraintErrorJSON{e.jsonFields(), e.ConstraintName, e.Column})
+}
+
+// LogValue adds the constraint to the DatabaseError fields.
+func (e *ForeignKeyError) LogValue() slog.Value {
+	return slog.GroupValue(constraintAttrs(e.logAttrs(), e.ConstraintName, e.Column)...)
+}
+
+func constraintAttrs(attrs []slog.Attr, name, column string) []slog.Attr {
+	if name != "" {
+		attrs = append(attrs, slog.String("constraint_name", name))
+	}
+	if column != "" {
+		attrs = append(attrs, slog.String("column", column))
+	}
+	return attrs
+}
+
+// poolExhaustedErrorJSON is the wire schema of PoolExhaustedError.
+type poolExhaustedErrorJSON struct {
+	databaseErrorJSON
+	Size   int    `json:"pool_size"`
+	InUse  int    `json:"in_use"`
+	Waited string `json:"waited"`
+}
+
+func (e *PoolExhaustedError) MarshalJSON() ([]byte, error) {
+	return json.Marshal(poolExhaustedErrorJSON{e.jsonFields(), e.Size, e.InUse, e.Waited.String()})
+}
+
+// LogValue adds the pool state to the DatabaseError fields.
+func (e *PoolExhaustedError) LogValue() slog.Value {
+	return slog.GroupValue(append(e.logAttrs(),
+		slog.Int("pool_size", e.Size),
+		slog.Int("in_use", e.InUse),
+		slog.Duration("waited", e.Waited),
+	)...)
+}
+
+// causeChain lists the messages of Chain(err).
+func causeChain(err error) []string {
+	var messages []string
+	for _, e := range Chain(err) {
+		messages = append(messages, e.Error())
+	}
+	return messages
+}
@@ -0,0 +1,157 @@
+package database
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleJSONError() *DatabaseError {
+	root := errors.New("i/o timeout")
+	return &DatabaseError{
+		Operation: "SELECT",
+		Table:     "users",
+		Query:     "SELECT * FROM users WHERE email = 'a@b.io'",
+		Err:       fmt.Errorf("read: %w", root),
+		Timestamp: time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC),
+		Retryable: true,
+		Kind:      KindTimeout,
+		TraceID:   "trace-1",
+	}
+}
+
+func TestDatabaseError_MarshalJSON(t *testing.T) {
+	data, err := json.Marshal(sampleJSONError())
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() error = %v", err)
+	}
+
+	expected := map[string]any{
+		"operation":   "SELECT",
+		"table":       "users",
+		"kind":        "timeout",
+		"retryable":   true,
+		"timestamp":   "2024-03-01T12:00:00Z",
+		"query":       "SELECT * FROM users WHERE email = ?",
+		"trace_id":    "trace-1",
+		"causes":      []any{"read: i/o timeout", "i/o timeout"},
+		"fingerprint": sampleJSONError().Fingerprint(),
+	}
+	if !reflect.DeepEqual(decoded, expected) {
+		t.Errorf("MarshalJSON() = %s\nwant %v", data, expected)
+	}
+}
+
+func TestDatabaseError_MarshalJSONJoinedCause(t *testing.T) {
+	e := &DatabaseError{Err: errors.Join(errors.New("insert failed"), errors.New("rollback failed"))}
+	data, err := json.Marshal(e)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"causes":["insert failed\nrollback failed","insert failed","rollback failed"]`) {
+		t.Errorf("MarshalJSON() = %s; want the joined branches flattened", data)
+	}
+}
+
+func TestDatabaseError_LogValue(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, nil))
+
+	logger.Error("query failed", "err", sampleJSONError())
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("log output is not JSON: %v: %s", err, buf.String())
+	}
+	group, ok := record["err"].(map[string]any)
+	if !ok {
+		t.Fatalf("err attribute = %v; want a group", record["err"])
+	}
+	if group["table"] != "users" || group["kind"] != "timeout" || group["retryable"] != true || group["fingerprint"] != sampleJSONError().Fingerprint() {
+		t.Errorf("err group = %v; want structured fields", group)
+	}
+	if strings.Contains(buf.String(), "a@b.io") {
+		t.Errorf("log output = %s; want the query sanitized", buf.String())
+	}
+}
+
+func TestDatabaseError_Details(t *testing.T) {
+	err := NewError("SELECT", "users", errors.New("i/o timeout"),
+		WithDetail("shard", 3), WithDetail("host", "db-2"), WithDetail("shard", 4))
+
+	if !reflect.DeepEqual(err.Details, map[string]any{"shard": 4, "host": "db-2"}) {
+		t.Errorf("Details = %v; want host and the last shard", err.Details)
+	}
+	if strings.Contains(err.Error(), "db-2") {
+		t.Errorf("Error() = %q; want details left out", err.Error())
+	}
+
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatalf("json.Marshal() error = %v", jsonErr)
+	}
+	if !strings.Contains(string(data), `"details":{"host":"db-2","shard":4}`) {
+		t.Errorf("MarshalJSON() = %s; want the details", data)
+	}
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Error("query failed", "err", err)
+	if !strings.Contains(buf.String(), `"details":{"host":"db-2","shard":4}`) {
+		t.Errorf("log output = %s; want the details group", buf.String())
+	}
+}
+
+func TestSubtypes_MarshalJSONAndLogValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected []string
+	}{
+		{
+			"unique constraint",
+			&UniqueConstraintError{DatabaseError: sampleJSONError(), ConstraintName: "users_email_key", Column: "email"},
+			[]string{`"constraint_name":"users_email_key"`, `"column":"email"`},
+		},
+		{
+			"foreign key",
+			&ForeignKeyError{DatabaseError: sampleJSONError(), ConstraintName: "orders_user_id_fkey", Column: "user_id"},
+			[]string{`"constraint_name":"orders_user_id_fkey"`, `"column":"user_id"`},
+		},
+		{
+			"pool exhausted",
+			&PoolExhaustedError{DatabaseError: sampleJSONError(), Size: 10, InUse: 10, Waited: 250 * time.Millisecond},
+			[]string{`"pool_size":10`, `"in_use":10`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.err)
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			var buf bytes.Buffer
+			slog.New(slog.NewJSONHandler(&buf, nil)).Error("query failed", "err", tt.err)
+
+			for _, field := range append(tt.expected, `"table":"users"`) {
+				if !strings.Contains(string(data), field) {
+					t.Errorf("MarshalJSON() = %s; want %s", data, field)
+				}
+				if !strings.Contains(buf.String(), field) {
+					t.Errorf("log output = %s; want %s", buf.String(), field)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package database
+
+// Event says why MetricsRecorder.Inc was called.
+type Event string
+
+const (
+	// EventError is a DatabaseError built by NewError, once per failure.
+	EventError Event = "error"
+	// EventRetry is Retry about to retry a failure, once per retry.
+	EventRetry Event = "retry"
+)
+
+// MetricsRecorder counts database failures. Inc is called with EventError
+// whenever NewError builds a DatabaseError and with EventRetry whenever
+// Retry is about to retry one, so failure and retry rates can be tracked
+// separately per operation, table and kind.
+type MetricsRecorder interface {
+	Inc(event Event, op, table string, kind Kind, retryable bool)
+}
+
+type noopRecorder struct{}
+
+func (noopRecorder) Inc(Event, string, string, Kind, bool) {}
+
+var metrics MetricsRecorder = noopRecorder{}
+
+// SetMetricsRecorder installs r and returns a function restoring the
+// previous recorder. A nil r disables recording.
+func SetMetricsRecorder(r MetricsRecorder) (restore func()) {
+	previous := metrics
+	if r == nil {
+		r = noopRecorder{}
+	}
+	metrics = r
+	return func() { metrics = previous }
+}
+
+func record(event Event, e *DatabaseError) {
+	metrics.Inc(event, e.Operation, e.Table, e.Kind, e.Retryable)
+}
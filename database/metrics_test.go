@@ -0,0 +1,70 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type recordedInc struct {
+	event     Event
+	op, table string
+	kind      Kind
+	retryable bool
+}
+
+type fakeRecorder struct {
+	calls []recordedInc
+}
+
+func (r *fakeRecorder) Inc(event Event, op, table string, kind Kind, retryable bool) {
+	r.calls = append(r.calls, recordedInc{event, op, table, kind, retryable})
+}
+
+func TestMetricsRecorder_NewError(t *testing.T) {
+	recorder := &fakeRecorder{}
+	defer SetMetricsRecorder(recorder)()
+
+	NewError("INSERT", "orders", errors.New("duplicate key"), WithKind(KindConstraintViolation))
+
+	expected := recordedInc{EventError, "INSERT", "orders", KindConstraintViolation, false}
+	if len(recorder.calls) != 1 || recorder.calls[0] != expected {
+		t.Errorf("recorded %v; want [%v]", recorder.calls, expected)
+	}
+}
+
+func TestMetricsRecorder_Retry(t *testing.T) {
+	defer SetClock(newFakeClock())()
+	recorder := &fakeRecorder{}
+	defer SetMetricsRecorder(recorder)()
+
+	timeout := &DatabaseError{Operation: "SELECT", Table: "users", Kind: KindTimeout, Err: errors.New("i/o timeout"), Retryable: true}
+	_ = Retry(context.Background(), RetryPolicy{MaxAttempts: 3}, func() error { return timeout })
+
+	// One call per retry; the final failed attempt is not retried
+	if len(recorder.calls) != 2 {
+		t.Fatalf("recorded %d calls; want 2", len(recorder.calls))
+	}
+	for _, call := range recorder.calls {
+		if call != (recordedInc{EventRetry, "SELECT", "users", KindTimeout, true}) {
+			t.Errorf("recorded %v; want the retried error's labels", call)
+		}
+	}
+}
+
+func TestSetMetricsRecorder_NilAndRestore(t *testing.T) {
+	recorder := &fakeRecorder{}
+	restore := SetMetricsRecorder(recorder)
+
+	restoreNil := SetMetricsRecorder(nil)
+	NewError("SELECT", "users", nil)
+	restoreNil()
+	restore()
+
+	if len(recorder.calls) != 0 {
+		t.Errorf("recorded %v while disabled; want nothing", recorder.calls)
+	}
+	if _, ok := metrics.(noopRecorder); !ok {
+		t.Errorf("metrics = %T after restore; want noopRecorder", metrics)
+	}
+}
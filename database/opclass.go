@@ -0,0 +1,88 @@
+package database
+
+import (
+	"errors"
+	"strings"
+)
+
+// OperationClass groups operations by what a retry elsewhere could do to
+// the data: reads are safe to repeat on a replica, writes and schema
+// changes are not.
+type OperationClass int
+
+const (
+	// OperationUnknown is any operation ClassifyOperation does not
+	// recognize. It is treated like a write.
+	OperationUnknown OperationClass = iota
+	// OperationRead covers SELECT and other statements that change nothing.
+	OperationRead
+	// OperationWrite covers INSERT, UPDATE, DELETE and similar DML.
+	OperationWrite
+	// OperationDDL covers schema changes such as CREATE and ALTER.
+	OperationDDL
+)
+
+func (c OperationClass) String() string {
+	switch c {
+	case OperationRead:
+		return "read"
+	case OperationWrite:
+		return "write"
+	case OperationDDL:
+		return "ddl"
+	}
+	return "unknown"
+}
+
+// Failoverable reports whether a failed operation of this class may be
+// sent to a replica. Only reads qualify; a write may already have been
+// applied on the primary, so replaying it elsewhere is never safe.
+func (c OperationClass) Failoverable() bool {
+	return c == OperationRead
+}
+
+var operationClasses = map[string]OperationClass{
+	"SELECT":   OperationRead,
+	"SHOW":     OperationRead,
+	"EXPLAIN":  OperationRead,
+	"DESCRIBE": OperationRead,
+	"INSERT":   OperationWrite,
+	"UPDATE":   OperationWrite,
+	"DELETE":   OperationWrite,
+	"MERGE":    OperationWrite,
+	"UPSERT":   OperationWrite,
+	"REPLACE":  OperationWrite,
+	"CREATE":   OperationDDL,
+	"ALTER":    OperationDDL,
+	"DROP":     OperationDDL,
+	"TRUNCATE": OperationDDL,
+	"RENAME":   OperationDDL,
+}
+
+// ClassifyOperation returns the class of op, which may be a bare verb such
+// as "SELECT" or a whole statement; only its first word is considered,
+// case-insensitively.
+func ClassifyOperation(op string) OperationClass {
+	fields := strings.Fields(op)
+	if len(fields) == 0 {
+		return OperationUnknown
+	}
+	return operationClasses[strings.ToUpper(fields[0])]
+}
+
+// OperationClass classifies e.Operation, falling back to the Query when the
+// operation alone is not recognized.
+func (e *DatabaseError) OperationClass() OperationClass {
+	if class := ClassifyOperation(e.Operation); class != OperationUnknown {
+		return class
+	}
+	return ClassifyOperation(e.Query)
+}
+
+// Failoverable reports whether err carries a retryable DatabaseError for a
+// read, i.e. one that can be retried against a replica. Failed writes are
+// never failoverable, even when IsRetryable accepts them.
+func Failoverable(err error) bool {
+	var dbErr *DatabaseError
+	return errors.As(err, &dbErr) && dbErr.OperationClass().Failoverable() && IsRetryable(err)
+}
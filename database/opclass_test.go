@@ -0,0 +1,65 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassifyOperation(t *testing.T) {
+	tests := []struct {
+		op       string
+		expected OperationClass
+	}{
+		{"SELECT", OperationRead},
+		{"select * from users where id = 1", OperationRead},
+		{"  EXPLAIN ANALYZE SELECT 1", OperationRead},
+		{"INSERT", OperationWrite},
+		{"update users set age = 2", OperationWrite},
+		{"DELETE", OperationWrite},
+		{"ALTER TABLE users ADD COLUMN name text", OperationDDL},
+		{"TRUNCATE users", OperationDDL},
+		{"ACQUIRE", OperationUnknown},
+		{"", OperationUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.op, func(t *testing.T) {
+			if got := ClassifyOperation(tt.op); got != tt.expected {
+				t.Errorf("ClassifyOperation(%q) = %v; want %v", tt.op, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDatabaseError_OperationClassFallsBackToQuery(t *testing.T) {
+	err := &DatabaseError{Operation: "query", Query: "SELECT * FROM users"}
+	if got := err.OperationClass(); got != OperationRead {
+		t.Errorf("OperationClass() = %v; want read from the query", got)
+	}
+}
+
+func TestFailoverable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"retryable read", &DatabaseError{Operation: "SELECT", Retryable: true, Kind: KindConnection}, true},
+		{"wrapped retryable read", fmt.Errorf("list: %w", &DatabaseError{Operation: "SELECT", Retryable: true}), true},
+		{"permanent read", &DatabaseError{Operation: "SELECT", Kind: KindNotFound}, false},
+		{"retryable write", &DatabaseError{Operation: "UPDATE", Retryable: true, Kind: KindDeadlock}, false},
+		{"retryable ddl", &DatabaseError{Operation: "ALTER", Retryable: true}, false},
+		{"unknown operation", &DatabaseError{Operation: "ACQUIRE", Retryable: true}, false},
+		{"not a database error", errors.New("boom"), false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Failoverable(tt.err); got != tt.expected {
+				t.Errorf("Failoverable(%v) = %v; want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
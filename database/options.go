@@ -0,0 +1,91 @@
+package database
+
+import "time"
+
+// Option configures a DatabaseError built by NewError.
+type Option func(*errorOptions)
+
+type errorOptions struct {
+	err          *DatabaseError
+	retryableSet bool
+}
+
+// WithOperation sets Operation, for constructors such as FromSQLState
+// that do not take it as an argument.
+func WithOperation(op string) Option {
+	return func(o *errorOptions) { o.err.Operation = op }
+}
+
+// WithTable sets Table, for constructors such as FromSQLState that do not
+// take it as an argument.
+func WithTable(table string) Option {
+	return func(o *errorOptions) { o.err.Table = table }
+}
+
+// WithQuery records the statement that failed.
+func WithQuery(query string) Option {
+	return func(o *errorOptions) { o.err.Query = query }
+}
+
+// WithRetryable sets Retryable explicitly, overriding the default derived
+// from WithKind regardless of option order.
+func WithRetryable(retryable bool) Option {
+	return func(o *errorOptions) {
+		o.err.Retryable = retryable
+		o.retryableSet = true
+	}
+}
+
+// WithKind sets Kind. Unless WithRetryable is also given, Retryable
+// follows Kind.Retryable.
+func WithKind(kind Kind) Option {
+	return func(o *errorOptions) { o.err.Kind = kind }
+}
+
+// WithRetryAfter sets RetryAfter, the wait Retry uses before the next
+// attempt in place of its backoff schedule.
+func WithRetryAfter(d time.Duration) Option {
+	return func(o *errorOptions) { o.err.RetryAfter = d }
+}
+
+// WithSQLState records the SQLSTATE code the driver reported.
+func WithSQLState(code string) Option {
+	return func(o *errorOptions) { o.err.SQLState = code }
+}
+
+// WithRowsAffected records how many rows the statement touched before it
+// failed, e.g. for partially applied batch updates.
+func WithRowsAffected(n int64) Option {
+	return func(o *errorOptions) { o.err.RowsAffected = n }
+}
+
+// WithDetail adds a diagnostic to Details under key, replacing any earlier
+// value for the same key.
+func WithDetail(key string, value any) Option {
+	return func(o *errorOptions) {
+		if o.err.Details == nil {
+			o.err.Details = make(map[string]any)
+		}
+		o.err.Details[key] = value
+	}
+}
+
+// NewError builds a DatabaseError stamped with the package clock's time, so
+// every package constructs errors the same way and tests can pin the
+// timestamp with SetClock. Each call is reported to the MetricsRecorder.
+func NewError(op, table string, cause error, opts ...Option) *DatabaseError {
+	o := errorOptions{err: &DatabaseError{
+		Operation: op,
+		Table:     table,
+		Err:       cause,
+		Timestamp: clock.Now(),
+	}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.retryableSet {
+		o.err.Retryable = o.err.Kind.Retryable()
+	}
+	record(EventError, o.err)
+	return o.err
+}
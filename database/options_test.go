@@ -0,0 +1,62 @@
+package database
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewError(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+
+	cause := errors.New("deadlock detected")
+	err := NewError("UPDATE", "accounts", cause,
+		WithQuery("UPDATE accounts SET balance = balance - 10"),
+		WithKind(KindDeadlock),
+		WithRowsAffected(3),
+	)
+
+	if err.Operation != "UPDATE" || err.Table != "accounts" || err.Err != cause {
+		t.Errorf("NewError() = %+v; want operation, table and cause set", err)
+	}
+	if err.Query != "UPDATE accounts SET balance = balance - 10" || err.RowsAffected != 3 {
+		t.Errorf("NewError() query = %q, rows = %d; want options applied", err.Query, err.RowsAffected)
+	}
+	if !err.Timestamp.Equal(fake.now) {
+		t.Errorf("Timestamp = %v; want the package clock's %v", err.Timestamp, fake.now)
+	}
+	if err.Kind != KindDeadlock || !err.Retryable {
+		t.Errorf("Kind = %v, Retryable = %v; want deadlock, true", err.Kind, err.Retryable)
+	}
+}
+
+func TestNewError_RetryableOverridesKind(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected bool
+	}{
+		{"default", nil, false},
+		{"kind only", []Option{WithKind(KindTimeout)}, true},
+		{"override after kind", []Option{WithKind(KindTimeout), WithRetryable(false)}, false},
+		{"override before kind", []Option{WithRetryable(false), WithKind(KindTimeout)}, false},
+		{"explicit retryable", []Option{WithRetryable(true)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewError("SELECT", "users", nil, tt.opts...).Retryable; got != tt.expected {
+				t.Errorf("Retryable = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewError_RealClock(t *testing.T) {
+	before := time.Now()
+	err := NewError("SELECT", "users", nil)
+	if err.Timestamp.Before(before) {
+		t.Errorf("Timestamp = %v; want at or after %v", err.Timestamp, before)
+	}
+}
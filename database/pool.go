@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrPoolExhausted is the cause of every PoolExhaustedError.
+var ErrPoolExhausted = errors.New("connection pool exhausted")
+
+// PoolExhaustedError reports that no connection freed up within the pool's
+// wait limit. It unwraps to a retryable DatabaseError of KindPoolExhausted,
+// so Retry backs off automatically; callers under load may prefer to shed
+// the request instead.
+type PoolExhaustedError struct {
+	*DatabaseError
+	Size   int
+	InUse  int
+	Waited time.Duration
+}
+
+func (e *PoolExhaustedError) Error() string {
+	return fmt.Sprintf("connection pool exhausted: %d of %d connections in use after waiting %v", e.InUse, e.Size, e.Waited)
+}
+
+func (e *PoolExhaustedError) Unwrap() error {
+	return e.DatabaseError
+}
+
+// Pool simulates a fixed-size connection pool for examples and tests of
+// backpressure handling. It hands out slots, not real connections.
+type Pool struct {
+	slots   chan struct{}
+	maxWait time.Duration
+}
+
+// NewPool returns a pool of size slots whose Acquire waits at most maxWait
+// for a slot to free up.
+func NewPool(size int, maxWait time.Duration) *Pool {
+	return &Pool{slots: make(chan struct{}, size), maxWait: maxWait}
+}
+
+// Size returns the pool capacity.
+func (p *Pool) Size() int {
+	return cap(p.slots)
+}
+
+// InUse returns the number of slots currently held.
+func (p *Pool) InUse() int {
+	return len(p.slots)
+}
+
+// Acquire takes a slot, waiting up to the pool's maxWait. The returned
+// release function must be called exactly once. It fails with a
+// *PoolExhaustedError when the wait runs out, or with ctx's error. The
+// wait is measured on the package clock, so SetClock controls it.
+func (p *Pool) Acquire(ctx context.Context) (release func(), err error) {
+	select {
+	case p.slots <- struct{}{}:
+		return p.release, nil
+	default:
+	}
+
+	clk := clock
+	start := clk.Now()
+	waitCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	expired := make(chan error, 1)
+	go func() { expired <- clk.Sleep(waitCtx, p.maxWait) }()
+	select {
+	case p.slots <- struct{}{}:
+		return p.release, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case sleepErr := <-expired:
+		if sleepErr != nil {
+			return nil, sleepErr
+		}
+		return nil, &PoolExhaustedError{
+			DatabaseError: NewError("ACQUIRE", "", ErrPoolExhausted, WithKind(KindPoolExhausted)),
+			Size:          p.Size(),
+			InUse:         p.InUse(),
+			Waited:        clk.Now().Sub(start),
+		}
+	}
+}
+
+func (p *Pool) release() {
+	<-p.slots
+}
@@ -0,0 +1,103 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPool_AcquireRelease(t *testing.T) {
+	pool := NewPool(2, time.Millisecond)
+
+	release1, err := pool.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	release2, err := pool.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	if pool.InUse() != 2 || pool.Size() != 2 {
+		t.Errorf("InUse() = %d, Size() = %d; want 2, 2", pool.InUse(), pool.Size())
+	}
+
+	release1()
+	release2()
+	if pool.InUse() != 0 {
+		t.Errorf("InUse() after release = %d; want 0", pool.InUse())
+	}
+}
+
+func TestPool_Exhausted(t *testing.T) {
+	pool := NewPool(1, 5*time.Millisecond)
+	release, err := pool.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() error = %v", err)
+	}
+	defer release()
+
+	_, err = pool.Acquire(context.Background())
+
+	var exhausted *PoolExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Acquire() = %v; want PoolExhaustedError", err)
+	}
+	if exhausted.Size != 1 || exhausted.InUse != 1 || exhausted.Waited < 5*time.Millisecond {
+		t.Errorf("PoolExhaustedError = %+v; want size 1, in use 1, waited >= 5ms", exhausted)
+	}
+	if !errors.Is(err, ErrPoolExhausted) || !IsRetryable(err) || KindOf(err) != KindPoolExhausted {
+		t.Error("PoolExhaustedError should be a retryable DatabaseError wrapping ErrPoolExhausted")
+	}
+}
+
+func TestPool_WaitsForRelease(t *testing.T) {
+	pool := NewPool(1, time.Second)
+	release, _ := pool.Acquire(context.Background())
+
+	go func() {
+		time.Sleep(5 * time.Millisecond)
+		release()
+	}()
+
+	release2, err := pool.Acquire(context.Background())
+	if err != nil {
+		t.Fatalf("Acquire() = %v; want a slot once the holder releases", err)
+	}
+	release2()
+}
+
+func TestPool_ContextCanceled(t *testing.T) {
+	pool := NewPool(1, time.Second)
+	release, _ := pool.Acquire(context.Background())
+	defer release()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := pool.Acquire(ctx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Acquire() = %v; want context.Canceled", err)
+	}
+}
+
+func TestPool_ExhaustedUsesClock(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+
+	pool := NewPool(1, time.Hour)
+	release, _ := pool.Acquire(context.Background())
+	defer release()
+
+	_, err := pool.Acquire(context.Background())
+
+	var exhausted *PoolExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Acquire() = %v; want PoolExhaustedError without waiting an hour", err)
+	}
+	if exhausted.Waited != time.Hour {
+		t.Errorf("Waited = %v; want the 1h measured on the fake clock", exhausted.Waited)
+	}
+	if !exhausted.Timestamp.Equal(fake.Now()) {
+		t.Errorf("Timestamp = %v; want the fake clock's %v", exhausted.Timestamp, fake.Now())
+	}
+}
@@ -0,0 +1,37 @@
+// Package prommetrics exports database failure counts to Prometheus by
+// implementing database.MetricsRecorder.
+package prommetrics
+
+import (
+	"strconv"
+
+	"go-error-handling/database"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Recorder counts database errors in a database_errors_total counter
+// labeled by event, op, table, kind and retryable. Filter on
+// event="error" to count each failure once; event="retry" counts the
+// retries Retry made on top.
+type Recorder struct {
+	errors *prometheus.CounterVec
+}
+
+// NewRecorder creates the counter and registers it with reg.
+func NewRecorder(reg prometheus.Registerer) (*Recorder, error) {
+	counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "database_errors_total",
+		Help: "Database errors and retries by event, operation, table, kind and retryability.",
+	}, []string{"event", "op", "table", "kind", "retryable"})
+	if err := reg.Register(counter); err != nil {
+		return nil, err
+	}
+	return &Recorder{errors: counter}, nil
+}
+
+// Inc implements database.MetricsRecorder, adding one to the series for
+// the given event and labels.
+func (r *Recorder) Inc(event database.Event, op, table string, kind database.Kind, retryable bool) {
+	r.errors.WithLabelValues(string(event), op, table, kind.String(), strconv.FormatBool(retryable)).Inc()
+}
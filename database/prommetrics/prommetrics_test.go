@@ -0,0 +1,62 @@
+package prommetrics
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-error-handling/database"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRecorder_CountsNewErrors(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	recorder, err := NewRecorder(reg)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	defer database.SetMetricsRecorder(recorder)()
+
+	database.NewError("SELECT", "users", errors.New("i/o timeout"), database.WithKind(database.KindTimeout))
+	database.NewError("SELECT", "users", errors.New("i/o timeout"), database.WithKind(database.KindTimeout))
+	database.NewError("INSERT", "orders", errors.New("duplicate key"), database.WithKind(database.KindConstraintViolation))
+
+	if got := testutil.ToFloat64(recorder.errors.WithLabelValues("error", "SELECT", "users", "timeout", "true")); got != 2 {
+		t.Errorf("users timeouts = %v; want 2", got)
+	}
+	if got := testutil.ToFloat64(recorder.errors.WithLabelValues("error", "INSERT", "orders", "constraint violation", "false")); got != 1 {
+		t.Errorf("orders constraint violations = %v; want 1", got)
+	}
+}
+
+func TestRecorder_SeparatesRetries(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	recorder, err := NewRecorder(reg)
+	if err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	defer database.SetMetricsRecorder(recorder)()
+	defer database.SetClock(database.FrozenClock{})()
+
+	failure := database.NewError("SELECT", "users", errors.New("i/o timeout"), database.WithKind(database.KindTimeout))
+	_ = database.Retry(context.Background(), database.RetryPolicy{MaxAttempts: 3}, func() error { return failure })
+
+	if got := testutil.ToFloat64(recorder.errors.WithLabelValues("error", "SELECT", "users", "timeout", "true")); got != 1 {
+		t.Errorf("errors = %v; want the failure counted once", got)
+	}
+	if got := testutil.ToFloat64(recorder.errors.WithLabelValues("retry", "SELECT", "users", "timeout", "true")); got != 2 {
+		t.Errorf("retries = %v; want 2", got)
+	}
+}
+
+func TestNewRecorder_DuplicateRegistration(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	if _, err := NewRecorder(reg); err != nil {
+		t.Fatalf("NewRecorder() error = %v", err)
+	}
+	if _, err := NewRecorder(reg); err == nil {
+		t.Error("NewRecorder() on the same registry should fail")
+	}
+}
@@ -0,0 +1,212 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+)
+
+// NonRetryableCauses lists substrings of cause messages that mark a failure
+// as permanent. A match overrides the Retryable flag because retrying a
+// logically broken statement can never succeed.
+var NonRetryableCauses = []string{
+	"syntax error",
+}
+
+// DefaultRetryDelay is the wait suggested by RetryDecision for retryable errors.
+const DefaultRetryDelay = 2 * time.Second
+
+// IsRetryable reports whether err carries a DatabaseError that is flagged
+// retryable and whose cause is not in NonRetryableCauses.
+func IsRetryable(err error) bool {
+	var dbErr *DatabaseError
+	if !errors.As(err, &dbErr) || !dbErr.Retryable {
+		return false
+	}
+	return !hasNonRetryableCause(dbErr)
+}
+
+// RetryDecision reports whether err should be retried and how long to wait
+// before the next attempt: the error's RetryAfter when set, otherwise
+// DefaultRetryDelay.
+func RetryDecision(err error) (retry bool, delay time.Duration) {
+	if !IsRetryable(err) {
+		return false, 0
+	}
+	if after := retryAfter(err); after > 0 {
+		return true, after
+	}
+	return true, DefaultRetryDelay
+}
+
+func retryAfter(err error) time.Duration {
+	var dbErr *DatabaseError
+	if errors.As(err, &dbErr) {
+		return dbErr.RetryAfter
+	}
+	return 0
+}
+
+// ExplainRetryable describes why RetryDecision would or would not retry err,
+// for logs and operator-facing reports.
+func ExplainRetryable(err error) string {
+	if err == nil {
+		return "no error"
+	}
+	var dbErr *DatabaseError
+	if !errors.As(err, &dbErr) {
+		return "not a database error"
+	}
+	if !dbErr.Retryable {
+		return "database error is flagged non-retryable"
+	}
+	if pattern, ok := nonRetryableCause(dbErr); ok {
+		return fmt.Sprintf("cause matches non-retryable pattern %q", pattern)
+	}
+	return "transient database error"
+}
+
+func hasNonRetryableCause(e *DatabaseError) bool {
+	_, ok := nonRetryableCause(e)
+	return ok
+}
+
+func nonRetryableCause(e *DatabaseError) (string, bool) {
+	if e.Err == nil {
+		return "", false
+	}
+	cause := strings.ToLower(e.Err.Error())
+	for _, pattern := range NonRetryableCauses {
+		if pattern != "" && strings.Contains(cause, strings.ToLower(pattern)) {
+			return pattern, true
+		}
+	}
+	return "", false
+}
+
+var (
+	// ErrRetryBudgetExceeded is returned (wrapping the last failure) when
+	// the retry policy runs out of wall-clock budget.
+	ErrRetryBudgetExceeded = errors.New("retry budget exceeded")
+	// ErrRetryAttemptsExhausted is returned (wrapping the last failure) when
+	// the retry policy runs out of attempts.
+	ErrRetryAttemptsExhausted = errors.New("retry attempts exhausted")
+)
+
+const (
+	baseBackoff = 100 * time.Millisecond
+	maxBackoff  = 5 * time.Second
+)
+
+// RetryPolicy controls Retry. Zero values disable the corresponding limit,
+// so callers should set at least MaxAttempts or Budget.
+type RetryPolicy struct {
+	// MaxAttempts caps the number of calls to the operation.
+	MaxAttempts int
+	// Budget caps total elapsed time, including the next backoff.
+	Budget time.Duration
+	// Jitter shortens each backoff by a random fraction of up to Jitter
+	// (0.2 sleeps 80-100% of it) so many clients failing together do not
+	// retry in lockstep. It is clamped to [0, 1].
+	Jitter float64
+	// OnRetry, when set, is called before each backoff sleep so callers can
+	// record metrics without wrapping the operation.
+	OnRetry func(attempt int, err error, next time.Duration)
+	// Breaker, when set, guards every attempt. Once it opens, Retry stops
+	// with ErrCircuitOpen instead of hammering the backend.
+	Breaker *Breaker
+	// DeadLetter, when set, receives operations that fail with a
+	// non-retryable DatabaseError before Retry returns the error.
+	DeadLetter DeadLetter
+}
+
+// jitterFraction returns a value in [0, 1); tests replace it to make
+// jittered delays deterministic.
+var jitterFraction = rand.Float64
+
+// Retry runs op until it succeeds, returns a non-retryable error, or the
+// policy's limits are reached. Only errors IsRetryable accepts are retried.
+// Delays grow exponentially with optional jitter, except that a failing
+// error's RetryAfter, when set, is used as the delay instead. Jitter only
+// lengthens a RetryAfter, which is a floor.
+func Retry(ctx context.Context, policy RetryPolicy, op func() error) error {
+	start := clock.Now()
+	for attempt := 1; ; attempt++ {
+		err := policy.run(op)
+		if err == nil {
+			return nil
+		}
+		if !IsRetryable(err) {
+			if policy.DeadLetter != nil {
+				ParkIfPermanent(policy.DeadLetter, err)
+			}
+			return err
+		}
+		if policy.MaxAttempts > 0 && attempt >= policy.MaxAttempts {
+			return fmt.Errorf("%w after %d attempts: %w", ErrRetryAttemptsExhausted, attempt, err)
+		}
+
+		delay := policy.delay(attempt, err)
+		if policy.Budget > 0 && clock.Now().Sub(start)+delay > policy.Budget {
+			return fmt.Errorf("%w after %d attempts: %w", ErrRetryBudgetExceeded, attempt, err)
+		}
+		var dbErr *DatabaseError
+		if errors.As(err, &dbErr) {
+			record(EventRetry, dbErr)
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, err, delay)
+		}
+		if sleepErr := clock.Sleep(ctx, delay); sleepErr != nil {
+			return fmt.Errorf("retry canceled after %d attempts: %w", attempt, errors.Join(sleepErr, err))
+		}
+	}
+}
+
+// RetryWithin retries op until the next backoff would push total elapsed
+// time past budget. Capping wall clock time matters more than attempt
+// counts when callers hold a request deadline.
+func RetryWithin(ctx context.Context, budget time.Duration, op func() error) error {
+	return Retry(ctx, RetryPolicy{Budget: budget}, op)
+}
+
+func (p RetryPolicy) run(op func() error) error {
+	if p.Breaker != nil {
+		return p.Breaker.Do(op)
+	}
+	return op()
+}
+
+// delay is the wait after a failed attempt: the error's RetryAfter when it
+// has one, the backoff otherwise. Jitter shortens the backoff but lengthens
+// the RetryAfter, so errors sharing a hint, such as every deadlock, are
+// not retried in lockstep yet never sooner than the hint allows.
+func (p RetryPolicy) delay(attempt int, err error) time.Duration {
+	jitter := min(max(p.Jitter, 0), 1)
+	if after := retryAfter(err); after > 0 {
+		if jitter > 0 {
+			after += time.Duration(float64(after) * jitter * jitterFraction())
+		}
+		return after
+	}
+	delay := backoff(attempt)
+	if jitter > 0 {
+		delay -= time.Duration(float64(delay) * jitter * jitterFraction())
+	}
+	return delay
+}
+
+// backoff doubles the delay per attempt, capped at maxBackoff.
+func backoff(attempt int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return delay
+}
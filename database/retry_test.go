@@ -0,0 +1,358 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryDecision_NonRetryableCauses(t *testing.T) {
+	tests := []struct {
+		name          string
+		cause         string
+		retryable     bool
+		expectedRetry bool
+	}{
+		{"syntax error overrides flag", "syntax error at or near \"SELEC\"", true, false},
+		{"connection timeout is retried", "connection timeout", true, true},
+		{"non-retryable flag is respected", "connection timeout", false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("query failed: %w", &DatabaseError{
+				Operation: "SELECT",
+				Table:     "users",
+				Err:       errors.New(tt.cause),
+				Timestamp: time.Now(),
+				Retryable: tt.retryable,
+			})
+
+			if got := IsRetryable(err); got != tt.expectedRetry {
+				t.Errorf("IsRetryable() = %v; want %v", got, tt.expectedRetry)
+			}
+
+			retry, delay := RetryDecision(err)
+			if retry != tt.expectedRetry {
+				t.Errorf("RetryDecision() retry = %v; want %v", retry, tt.expectedRetry)
+			}
+			if retry && delay != DefaultRetryDelay {
+				t.Errorf("RetryDecision() delay = %v; want %v", delay, DefaultRetryDelay)
+			}
+			if !retry && delay != 0 {
+				t.Errorf("RetryDecision() delay = %v; want 0 when not retrying", delay)
+			}
+		})
+	}
+}
+
+func TestRetryDecision_ConfigurableCauses(t *testing.T) {
+	original := NonRetryableCauses
+	defer func() { NonRetryableCauses = original }()
+
+	NonRetryableCauses = append([]string{}, original...)
+	NonRetryableCauses = append(NonRetryableCauses, "permission denied")
+
+	err := &DatabaseError{
+		Operation: "UPDATE",
+		Table:     "accounts",
+		Err:       errors.New("Permission Denied for relation accounts"),
+		Timestamp: time.Now(),
+		Retryable: true,
+	}
+
+	if IsRetryable(err) {
+		t.Error("IsRetryable should honour causes added to NonRetryableCauses")
+	}
+}
+
+func TestIsRetryable_NonDatabaseError(t *testing.T) {
+	if IsRetryable(errors.New("plain error")) {
+		t.Error("IsRetryable(plain error) should be false")
+	}
+	if IsRetryable(nil) {
+		t.Error("IsRetryable(nil) should be false")
+	}
+}
+
+func TestRetryWithin_BudgetExceeded(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+
+	attempts := 0
+	timeout := &DatabaseError{
+		Operation: "SELECT",
+		Table:     "users",
+		Err:       errors.New("connection timeout"),
+		Timestamp: time.Now(),
+		Retryable: true,
+	}
+
+	budget := time.Second
+	err := RetryWithin(context.Background(), budget, func() error {
+		attempts++
+		return timeout
+	})
+
+	if !errors.Is(err, ErrRetryBudgetExceeded) {
+		t.Fatalf("RetryWithin() = %v; want ErrRetryBudgetExceeded", err)
+	}
+	if !errors.Is(err, timeout) {
+		t.Error("RetryWithin() should wrap the last operation error")
+	}
+	if !strings.Contains(err.Error(), "retry budget exceeded") {
+		t.Errorf("RetryWithin() message should mention the budget, got: %s", err.Error())
+	}
+
+	// 100ms + 200ms + 400ms fit in one second; the next 800ms would not
+	if attempts != 4 {
+		t.Errorf("RetryWithin() made %d attempts; want 4", attempts)
+	}
+	if elapsed := fake.now.Sub(newFakeClock().now); elapsed > budget {
+		t.Errorf("RetryWithin() slept %v; want at most %v", elapsed, budget)
+	}
+}
+
+func TestRetryWithin_SucceedsAfterRetry(t *testing.T) {
+	defer SetClock(newFakeClock())()
+
+	attempts := 0
+	err := RetryWithin(context.Background(), time.Minute, func() error {
+		attempts++
+		if attempts < 3 {
+			return &DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("connection timeout"), Retryable: true}
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Errorf("RetryWithin() returned unexpected error: %v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("RetryWithin() made %d attempts; want 3", attempts)
+	}
+}
+
+func TestRetryWithin_NonRetryableStopsImmediately(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+
+	permanent := &DatabaseError{Operation: "INSERT", Table: "users", Err: errors.New("constraint violation"), Retryable: false}
+	attempts := 0
+	err := RetryWithin(context.Background(), time.Minute, func() error {
+		attempts++
+		return permanent
+	})
+
+	if err != permanent {
+		t.Errorf("RetryWithin() = %v; want the non-retryable error unchanged", err)
+	}
+	if attempts != 1 || len(fake.sleeps) != 0 {
+		t.Errorf("RetryWithin() made %d attempts and %d sleeps; want 1 and 0", attempts, len(fake.sleeps))
+	}
+}
+
+func TestRetryWithin_ContextCanceled(t *testing.T) {
+	defer SetClock(newFakeClock())()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	err := RetryWithin(ctx, time.Minute, func() error {
+		return &DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("connection timeout"), Retryable: true}
+	})
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("RetryWithin() with canceled context = %v; want context.Canceled", err)
+	}
+}
+
+func TestBackoff_Capped(t *testing.T) {
+	tests := []struct {
+		attempt  int
+		expected time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{20, maxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := backoff(tt.attempt); got != tt.expected {
+			t.Errorf("backoff(%d) = %v; want %v", tt.attempt, got, tt.expected)
+		}
+	}
+}
+
+func TestRetry_OnRetryHook(t *testing.T) {
+	defer SetClock(newFakeClock())()
+
+	type call struct {
+		attempt int
+		err     error
+		next    time.Duration
+	}
+	var calls []call
+
+	timeout := &DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("connection timeout"), Retryable: true}
+	policy := RetryPolicy{
+		MaxAttempts: 4,
+		OnRetry: func(attempt int, err error, next time.Duration) {
+			calls = append(calls, call{attempt, err, next})
+		},
+	}
+
+	err := Retry(context.Background(), policy, func() error { return timeout })
+
+	if !errors.Is(err, ErrRetryAttemptsExhausted) || !errors.Is(err, timeout) {
+		t.Fatalf("Retry() = %v; want ErrRetryAttemptsExhausted wrapping the last error", err)
+	}
+
+	// The hook runs before each sleep, so never after the final attempt
+	if len(calls) != policy.MaxAttempts-1 {
+		t.Fatalf("OnRetry called %d times; want %d", len(calls), policy.MaxAttempts-1)
+	}
+	for i, c := range calls {
+		if c.attempt != i+1 {
+			t.Errorf("call %d attempt = %d; want %d", i, c.attempt, i+1)
+		}
+		if c.next != backoff(i+1) {
+			t.Errorf("call %d next delay = %v; want %v", i, c.next, backoff(i+1))
+		}
+		if c.err != timeout {
+			t.Errorf("call %d err = %v; want %v", i, c.err, timeout)
+		}
+	}
+}
+
+func TestRetry_OnRetryNotCalledOnSuccess(t *testing.T) {
+	defer SetClock(newFakeClock())()
+
+	called := false
+	policy := RetryPolicy{
+		MaxAttempts: 3,
+		OnRetry:     func(int, error, time.Duration) { called = true },
+	}
+
+	if err := Retry(context.Background(), policy, func() error { return nil }); err != nil {
+		t.Errorf("Retry() returned unexpected error: %v", err)
+	}
+	if called {
+		t.Error("OnRetry should not be called when the first attempt succeeds")
+	}
+}
+
+func TestRetry_NilHook(t *testing.T) {
+	defer SetClock(newFakeClock())()
+
+	attempts := 0
+	err := Retry(context.Background(), RetryPolicy{MaxAttempts: 2}, func() error {
+		attempts++
+		return &DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("connection timeout"), Retryable: true}
+	})
+
+	if !errors.Is(err, ErrRetryAttemptsExhausted) {
+		t.Errorf("Retry() = %v; want ErrRetryAttemptsExhausted", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Retry() made %d attempts; want 2", attempts)
+	}
+}
+
+func TestExplainRetryable(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"nil error", nil, "no error"},
+		{"plain error", errors.New("plain"), "not a database error"},
+		{
+			"flagged non-retryable",
+			&DatabaseError{Operation: "INSERT", Table: "users", Err: errors.New("duplicate key"), Retryable: false},
+			"database error is flagged non-retryable",
+		},
+		{
+			"blacklisted cause",
+			&DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("syntax error near FROM"), Retryable: true},
+			`cause matches non-retryable pattern "syntax error"`,
+		},
+		{
+			"transient failure",
+			&DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("connection timeout"), Retryable: true},
+			"transient database error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExplainRetryable(tt.err); got != tt.expected {
+				t.Errorf("ExplainRetryable() = %q; want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetry_HonorsRetryAfter(t *testing.T) {
+	fake := newFakeClock()
+	defer SetClock(fake)()
+
+	locked := &DatabaseError{Operation: "UPDATE", Table: "users", Err: errors.New("lock timeout"), Retryable: true, RetryAfter: time.Second}
+	err := Retry(context.Background(), RetryPolicy{MaxAttempts: 3}, func() error { return locked })
+
+	if !errors.Is(err, ErrRetryAttemptsExhausted) {
+		t.Fatalf("Retry() = %v; want ErrRetryAttemptsExhausted", err)
+	}
+	for i, slept := range fake.sleeps {
+		if slept != time.Second {
+			t.Errorf("sleep %d = %v; want RetryAfter of 1s over the shorter backoff", i, slept)
+		}
+	}
+
+	if retry, delay := RetryDecision(locked); !retry || delay != time.Second {
+		t.Errorf("RetryDecision() = %v, %v; want true, 1s", retry, delay)
+	}
+}
+
+func TestRetryPolicy_Jitter(t *testing.T) {
+	original := jitterFraction
+	defer func() { jitterFraction = original }()
+	jitterFraction = func() float64 { return 0.5 }
+
+	timeout := &DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("connection timeout"), Retryable: true}
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		err      error
+		expected time.Duration
+	}{
+		{"no jitter", RetryPolicy{}, timeout, 400 * time.Millisecond},
+		{"half of 20% jitter", RetryPolicy{Jitter: 0.2}, timeout, 360 * time.Millisecond},
+		{"jitter clamped to 1", RetryPolicy{Jitter: 3}, timeout, 200 * time.Millisecond},
+		{"negative jitter ignored", RetryPolicy{Jitter: -1}, timeout, 400 * time.Millisecond},
+		{
+			"RetryAfter replaces backoff",
+			RetryPolicy{},
+			&DatabaseError{Err: errors.New("serialization failure"), Retryable: true, RetryAfter: 20 * time.Millisecond},
+			20 * time.Millisecond,
+		},
+		{
+			"RetryAfter jittered upward",
+			RetryPolicy{Jitter: 0.5},
+			&DatabaseError{Err: errors.New("serialization failure"), Retryable: true, RetryAfter: 20 * time.Millisecond},
+			25 * time.Millisecond,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.delay(3, tt.err); got != tt.expected {
+				t.Errorf("delay(3) = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package database
+
+import "regexp"
+
+// QuerySanitizer rewrites a query before it appears in Error() or logs.
+type QuerySanitizer func(query string) string
+
+// Sanitizer is applied by SafeQuery. It defaults to MaskLiterals; set it to
+// nil to print queries verbatim, e.g. in local development.
+var Sanitizer QuerySanitizer = MaskLiterals
+
+var (
+	stringLiteral  = regexp.MustCompile(`'(?:[^']|'')*'`)
+	numericLiteral = regexp.MustCompile(`(^|[^\w$.])(\d+(?:\.\d+)?)\b`)
+)
+
+// MaskLiterals replaces quoted strings and numeric literals with "?", so
+// emails, tokens and IDs in WHERE clauses never reach logs. Identifiers such
+// as users2 and placeholders such as $1 are left alone.
+func MaskLiterals(query string) string {
+	query = stringLiteral.ReplaceAllString(query, "?")
+	return numericLiteral.ReplaceAllString(query, "${1}?")
+}
+
+// SafeQuery returns Query passed through Sanitizer. Error() prints this form.
+func (e *DatabaseError) SafeQuery() string {
+	if Sanitizer == nil {
+		return e.Query
+	}
+	return Sanitizer(e.Query)
+}
+
+// RawQuery returns Query exactly as recorded, for interactive debugging.
+// Never log it: it may contain user data.
+func (e *DatabaseError) RawQuery() string {
+	return e.Query
+}
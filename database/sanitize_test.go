@@ -0,0 +1,70 @@
+package database
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMaskLiterals(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT * FROM users WHERE email = 'a@b.io'", "SELECT * FROM users WHERE email = ?"},
+		{"SELECT * FROM users WHERE name = 'O''Brien' AND id = 42", "SELECT * FROM users WHERE name = ? AND id = ?"},
+		{"SELECT * FROM users LIMIT 10", "SELECT * FROM users LIMIT ?"},
+		{"UPDATE t SET price = 9.99 WHERE id IN (1,2)", "UPDATE t SET price = ? WHERE id IN (?,?)"},
+		{"SELECT * FROM users2 WHERE id = $1", "SELECT * FROM users2 WHERE id = $1"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := MaskLiterals(tt.query); got != tt.expected {
+			t.Errorf("MaskLiterals(%q) = %q; want %q", tt.query, got, tt.expected)
+		}
+	}
+}
+
+func TestDatabaseError_QueryRedaction(t *testing.T) {
+	query := "SELECT * FROM sessions WHERE token = 'tok_secret123'"
+	err := &DatabaseError{
+		Operation: "SELECT",
+		Table:     "sessions",
+		Query:     query,
+		Err:       errors.New("connection timeout"),
+		Timestamp: time.Now(),
+	}
+
+	if msg := err.Error(); strings.Contains(msg, "tok_secret123") || !strings.Contains(msg, "query: SELECT * FROM sessions WHERE token = ?") {
+		t.Errorf("Error() = %q; want the masked query", msg)
+	}
+	if err.RawQuery() != query {
+		t.Errorf("RawQuery() = %q; want %q", err.RawQuery(), query)
+	}
+}
+
+func TestDatabaseError_CustomSanitizer(t *testing.T) {
+	original := Sanitizer
+	defer func() { Sanitizer = original }()
+
+	err := &DatabaseError{Query: "SELECT 1"}
+
+	Sanitizer = func(string) string { return "<redacted>" }
+	if got := err.SafeQuery(); got != "<redacted>" {
+		t.Errorf("SafeQuery() = %q; want the custom sanitizer output", got)
+	}
+
+	Sanitizer = nil
+	if got := err.SafeQuery(); got != "SELECT 1" {
+		t.Errorf("SafeQuery() with nil Sanitizer = %q; want the raw query", got)
+	}
+}
+
+func TestDatabaseError_NoQuery(t *testing.T) {
+	err := &DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("boom")}
+	if strings.Contains(err.Error(), "query:") {
+		t.Errorf("Error() = %q; want no query section", err.Error())
+	}
+}
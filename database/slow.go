@@ -0,0 +1,45 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// SlowQueryThreshold is the budget Trace compares operations against.
+// Zero disables slow query reporting.
+var SlowQueryThreshold = 500 * time.Millisecond
+
+// SlowQueryError is a warning, not a failure: the operation succeeded but
+// took longer than Threshold. Callers typically log it and carry on.
+type SlowQueryError struct {
+	Duration  time.Duration
+	Threshold time.Duration
+	// TraceID comes from the context passed to Trace, if any.
+	TraceID string
+}
+
+func (e *SlowQueryError) Error() string {
+	msg := fmt.Sprintf("slow query: took %v, threshold %v", e.Duration, e.Threshold)
+	if e.TraceID != "" {
+		msg += " (trace: " + e.TraceID + ")"
+	}
+	return msg
+}
+
+// Trace runs fn and times it with the package clock. A failure from fn is
+// returned unchanged, since a hard error matters more than its latency;
+// a successful call that exceeded SlowQueryThreshold returns a
+// *SlowQueryError instead of nil.
+func Trace(ctx context.Context, fn func(ctx context.Context) error) error {
+	start := clock.Now()
+	if err := fn(ctx); err != nil {
+		return err
+	}
+	elapsed := clock.Now().Sub(start)
+	if SlowQueryThreshold > 0 && elapsed > SlowQueryThreshold {
+		traceID, _ := TraceFromContext(ctx)
+		return &SlowQueryError{Duration: elapsed, Threshold: SlowQueryThreshold, TraceID: traceID}
+	}
+	return nil
+}
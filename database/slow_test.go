@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestTrace(t *testing.T) {
+	defer SetClock(newFakeClock())()
+	hard := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		latency  time.Duration
+		err      error
+		wantSlow bool
+	}{
+		{"fast success", 10 * time.Millisecond, nil, false},
+		{"at threshold", SlowQueryThreshold, nil, false},
+		{"slow success", 2 * time.Second, nil, true},
+		{"slow failure keeps hard error", 2 * time.Second, hard, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := ContextWithTrace(context.Background(), "trace-7", "")
+			err := Trace(ctx, func(ctx context.Context) error {
+				_ = clock.Sleep(ctx, tt.latency)
+				return tt.err
+			})
+
+			var slow *SlowQueryError
+			if got := errors.As(err, &slow); got != tt.wantSlow {
+				t.Fatalf("Trace() = %v; slow = %v, want %v", err, got, tt.wantSlow)
+			}
+			if tt.wantSlow && (slow.Duration != tt.latency || slow.Threshold != SlowQueryThreshold || slow.TraceID != "trace-7") {
+				t.Errorf("SlowQueryError = %+v; want duration %v and the trace ID", slow, tt.latency)
+			}
+			if tt.err != nil && err != tt.err {
+				t.Errorf("Trace() = %v; want the hard error unchanged", err)
+			}
+		})
+	}
+}
+
+func TestTrace_WithFakeLatency(t *testing.T) {
+	defer SetClock(newFakeClock())()
+	store := NewFake().Insert("users", "ana").WithLatency(time.Second)
+
+	err := Trace(context.Background(), func(ctx context.Context) error {
+		_, err := store.Query(ctx, "users", 1)
+		return err
+	})
+
+	var slow *SlowQueryError
+	if !errors.As(err, &slow) || IsDatabaseError(err) {
+		t.Errorf("Trace() = %v; want a SlowQueryError distinct from database failures", err)
+	}
+}
+
+func TestTrace_Disabled(t *testing.T) {
+	defer SetClock(newFakeClock())()
+	original := SlowQueryThreshold
+	defer func() { SlowQueryThreshold = original }()
+	SlowQueryThreshold = 0
+
+	err := Trace(context.Background(), func(ctx context.Context) error {
+		return clock.Sleep(ctx, time.Hour)
+	})
+	if err != nil {
+		t.Errorf("Trace() with threshold 0 = %v; want nil", err)
+	}
+}
@@ -0,0 +1,57 @@
+package database
+
+// SQLStateMapping is how a SQLSTATE code or class is classified.
+type SQLStateMapping struct {
+	Kind      Kind
+	Retryable bool
+}
+
+// SQLStates maps SQLSTATE codes to Kind and Retryable. Keys are either full
+// five-character codes or two-character classes; FromSQLState tries the
+// full code first. The table covers PostgreSQL and the MySQL codes that
+// differ from it, and may be extended at init time.
+var SQLStates = map[string]SQLStateMapping{
+	// Class 02: no data
+	"02": {KindNotFound, false},
+	// Class 08: connection exception
+	"08": {KindConnection, true},
+	// Class 23: integrity constraint violation (MySQL reports 23000)
+	"23": {KindConstraintViolation, false},
+	// Class 28: invalid authorization
+	"28": {KindPermissionDenied, false},
+	// Class 40: transaction rollback. 40001 is a serialization failure in
+	// PostgreSQL and a deadlock in MySQL; both succeed when retried.
+	"40":    {KindUnknown, true},
+	"40001": {KindDeadlock, true},
+	"40P01": {KindDeadlock, true},
+	// Class 42: syntax error or access rule violation
+	"42":    {KindUnknown, false},
+	"42501": {KindPermissionDenied, false},
+	// Class 53: insufficient resources
+	"53":    {KindUnknown, true},
+	"53300": {KindPoolExhausted, true},
+	// Class 55: object not in prerequisite state
+	"55P03": {KindTimeout, true},
+	// Class 57: operator intervention
+	"57014": {KindTimeout, true},
+	"57P01": {KindConnection, true},
+}
+
+// FromSQLState classifies a driver-reported SQLSTATE code like Classify:
+// Kind and Retryable come from SQLStates and, when retryable, RetryAfter
+// from RetryAfterByKind. Deadlocks and constraint violations come back as
+// DeadlockError, UniqueConstraintError and ForeignKeyError. Unknown codes
+// get KindUnknown and are not retried. opts are applied after the
+// SQLSTATE defaults; pass WithOperation and WithTable to fill in where the
+// failure happened.
+func FromSQLState(code string, cause error, opts ...Option) error {
+	mapping, ok := SQLStates[code]
+	if !ok && len(code) >= 2 {
+		mapping = SQLStates[code[:2]]
+	}
+	defaults := []Option{WithKind(mapping.Kind), WithRetryable(mapping.Retryable), WithSQLState(code)}
+	if mapping.Retryable {
+		defaults = append(defaults, WithRetryAfter(RetryAfterByKind[mapping.Kind]))
+	}
+	return specialize(NewError("", "", cause, append(defaults, opts...)...))
+}
@@ -0,0 +1,107 @@
+package database
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFromSQLState(t *testing.T) {
+	tests := []struct {
+		code          string
+		expectedKind  Kind
+		expectedRetry bool
+	}{
+		{"23505", KindConstraintViolation, false},
+		{"23000", KindConstraintViolation, false},
+		{"40P01", KindDeadlock, true},
+		{"40001", KindDeadlock, true},
+		{"40002", KindUnknown, true},
+		{"08006", KindConnection, true},
+		{"57014", KindTimeout, true},
+		{"42501", KindPermissionDenied, false},
+		{"42601", KindUnknown, false},
+		{"53300", KindPoolExhausted, true},
+		{"XX000", KindUnknown, false},
+		{"", KindUnknown, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			cause := errors.New("driver failure")
+			var err *DatabaseError
+			if !errors.As(FromSQLState(tt.code, cause, WithOperation("SELECT"), WithTable("users")), &err) {
+				t.Fatalf("FromSQLState(%q) should return a DatabaseError", tt.code)
+			}
+
+			if err.Kind != tt.expectedKind || err.Retryable != tt.expectedRetry {
+				t.Errorf("FromSQLState(%q) kind = %v, retryable = %v; want %v, %v", tt.code, err.Kind, err.Retryable, tt.expectedKind, tt.expectedRetry)
+			}
+			if err.SQLState != tt.code || err.Err != cause || err.Timestamp.IsZero() || err.Operation != "SELECT" || err.Table != "users" {
+				t.Errorf("FromSQLState(%q) = %+v; want code, cause, operation, table and timestamp set", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestFromSQLState_RetryAfter(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected time.Duration
+	}{
+		{"40001", 20 * time.Millisecond},
+		{"57014", time.Second},
+		{"08006", 500 * time.Millisecond},
+		{"40002", 0},
+		{"23505", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			var dbErr *DatabaseError
+			errors.As(FromSQLState(tt.code, nil), &dbErr)
+			if got := dbErr.RetryAfter; got != tt.expected {
+				t.Errorf("FromSQLState(%q).RetryAfter = %v; want %v", tt.code, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDatabaseError_SQLStateOutput(t *testing.T) {
+	err := FromSQLState("23505", errors.New("duplicate key"))
+
+	if !strings.Contains(err.Error(), "sqlstate: 23505") {
+		t.Errorf("Error() = %q; want the SQLSTATE", err.Error())
+	}
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatalf("json.Marshal() error = %v", jsonErr)
+	}
+	if !strings.Contains(string(data), `"sqlstate":"23505"`) {
+		t.Errorf("MarshalJSON() = %s; want the SQLSTATE", data)
+	}
+}
+
+func TestFromSQLState_SubtypesAndMetrics(t *testing.T) {
+	recorder := &fakeRecorder{}
+	defer SetMetricsRecorder(recorder)()
+
+	var deadlock *DeadlockError
+	if !errors.As(FromSQLState("40P01", errors.New("deadlock detected"), WithOperation("UPDATE"), WithTable("orders")), &deadlock) {
+		t.Error("FromSQLState(40P01) should return a DeadlockError, like Classify")
+	}
+	var unique *UniqueConstraintError
+	if !errors.As(FromSQLState("23505", errors.New(`duplicate key value violates unique constraint "users_email_key"`), WithOperation("INSERT"), WithTable("users")), &unique) {
+		t.Error("FromSQLState(23505) should return a UniqueConstraintError")
+	}
+
+	expected := []recordedInc{
+		{EventError, "UPDATE", "orders", KindDeadlock, true},
+		{EventError, "INSERT", "users", KindConstraintViolation, false},
+	}
+	if len(recorder.calls) != len(expected) || recorder.calls[0] != expected[0] || recorder.calls[1] != expected[1] {
+		t.Errorf("recorded %v; want %v", recorder.calls, expected)
+	}
+}
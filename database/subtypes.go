@@ -0,0 +1,81 @@
+package database
+
+import (
+	"regexp"
+	"strings"
+)
+
+// UniqueConstraintError is a DatabaseError caused by a duplicate key.
+// It unwraps to the DatabaseError, so errors.As works for both types.
+type UniqueConstraintError struct {
+	*DatabaseError
+	ConstraintName string
+	Column         string
+}
+
+func (e *UniqueConstraintError) Unwrap() error {
+	return e.DatabaseError
+}
+
+// ForeignKeyError is a DatabaseError caused by a missing or still
+// referenced row in another table.
+type ForeignKeyError struct {
+	*DatabaseError
+	ConstraintName string
+	Column         string
+}
+
+func (e *ForeignKeyError) Unwrap() error {
+	return e.DatabaseError
+}
+
+// DeadlockError is a DatabaseError whose transaction was chosen as a
+// deadlock victim. It is always retryable.
+type DeadlockError struct {
+	*DatabaseError
+}
+
+func (e *DeadlockError) Unwrap() error {
+	return e.DatabaseError
+}
+
+var (
+	// constraintName matches PostgreSQL (constraint "users_email_key") and
+	// MySQL (for key 'users.email') messages.
+	constraintName = regexp.MustCompile(`constraint "([^"]+)"|for key '([^']+)'`)
+	// keyColumn matches the PostgreSQL detail line: Key (email)=(a@b.io).
+	keyColumn = regexp.MustCompile(`Key \(([^)]+)\)=`)
+)
+
+// specialize wraps a classified DatabaseError in the matching subtype, or
+// returns it unchanged when none applies.
+func specialize(e *DatabaseError) error {
+	switch e.Kind {
+	case KindDeadlock:
+		return &DeadlockError{DatabaseError: e}
+	case KindConstraintViolation:
+		if e.Err == nil {
+			return e
+		}
+		msg := e.Err.Error()
+		name, column := parseConstraint(msg)
+		lower := strings.ToLower(msg)
+		if strings.Contains(lower, "foreign key") {
+			return &ForeignKeyError{DatabaseError: e, ConstraintName: name, Column: column}
+		}
+		if strings.Contains(lower, "duplicate") || strings.Contains(lower, "unique") {
+			return &UniqueConstraintError{DatabaseError: e, ConstraintName: name, Column: column}
+		}
+	}
+	return e
+}
+
+func parseConstraint(msg string) (name, column string) {
+	if m := constraintName.FindStringSubmatch(msg); m != nil {
+		name = m[1] + m[2]
+	}
+	if m := keyColumn.FindStringSubmatch(msg); m != nil {
+		column = m[1]
+	}
+	return name, column
+}
@@ -0,0 +1,76 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestClassify_UniqueConstraint(t *testing.T) {
+	cause := errors.New(`pq: duplicate key value violates unique constraint "users_email_key" Key (email)=(a@b.io) already exists.`)
+	err := fmt.Errorf("create user: %w", Classify(cause, "INSERT", "users"))
+
+	var unique *UniqueConstraintError
+	if !errors.As(err, &unique) {
+		t.Fatalf("Classify() = %v; want UniqueConstraintError", err)
+	}
+	if unique.ConstraintName != "users_email_key" || unique.Column != "email" {
+		t.Errorf("constraint = %q, column = %q; want users_email_key, email", unique.ConstraintName, unique.Column)
+	}
+
+	var dbErr *DatabaseError
+	if !errors.As(err, &dbErr) || dbErr.Table != "users" {
+		t.Errorf("errors.As(*DatabaseError) = %v; want the embedded DatabaseError", dbErr)
+	}
+	if !errors.Is(err, cause) || !IsConstraint(err) {
+		t.Error("UniqueConstraintError should unwrap to the cause and keep its Kind")
+	}
+}
+
+func TestClassify_MySQLDuplicate(t *testing.T) {
+	err := Classify(errors.New("Error 1062: Duplicate entry 'a@b.io' for key 'users.email'"), "INSERT", "users")
+
+	var unique *UniqueConstraintError
+	if !errors.As(err, &unique) || unique.ConstraintName != "users.email" {
+		t.Errorf("Classify() = %#v; want UniqueConstraintError for users.email", err)
+	}
+}
+
+func TestClassify_ForeignKey(t *testing.T) {
+	cause := errors.New(`insert or update on table "orders" violates foreign key constraint "orders_user_id_fkey" Key (user_id)=(7) is not present`)
+	err := Classify(cause, "INSERT", "orders")
+
+	var fk *ForeignKeyError
+	if !errors.As(err, &fk) {
+		t.Fatalf("Classify() = %v; want ForeignKeyError", err)
+	}
+	if fk.ConstraintName != "orders_user_id_fkey" || fk.Column != "user_id" {
+		t.Errorf("constraint = %q, column = %q; want orders_user_id_fkey, user_id", fk.ConstraintName, fk.Column)
+	}
+	var unique *UniqueConstraintError
+	if errors.As(err, &unique) {
+		t.Error("a foreign key violation should not match UniqueConstraintError")
+	}
+}
+
+func TestClassify_Deadlock(t *testing.T) {
+	err := Classify(errors.New("ERROR: deadlock detected"), "UPDATE", "accounts")
+
+	var deadlock *DeadlockError
+	if !errors.As(err, &deadlock) {
+		t.Fatalf("Classify() = %v; want DeadlockError", err)
+	}
+	if !IsRetryable(err) {
+		t.Error("DeadlockError should be retryable through the embedded DatabaseError")
+	}
+	if deadlock.Error() != deadlock.DatabaseError.Error() {
+		t.Error("DeadlockError should report the DatabaseError message")
+	}
+}
+
+func TestClassify_OtherKindsStayPlain(t *testing.T) {
+	err := Classify(errors.New("connection refused"), "SELECT", "users")
+	if _, ok := err.(*DatabaseError); !ok {
+		t.Errorf("Classify() = %T; want *DatabaseError", err)
+	}
+}
@@ -0,0 +1,38 @@
+package database
+
+import "context"
+
+type traceKey struct{}
+
+type traceIDs struct {
+	traceID, spanID string
+}
+
+// ContextWithTrace returns a copy of ctx carrying the trace and span IDs
+// that NewErrorFromContext copies into DatabaseError. Tracing middleware
+// should call it once per request or span.
+func ContextWithTrace(ctx context.Context, traceID, spanID string) context.Context {
+	return context.WithValue(ctx, traceKey{}, traceIDs{traceID: traceID, spanID: spanID})
+}
+
+// TraceFromContext returns the IDs stored by ContextWithTrace, or empty
+// strings when there are none.
+func TraceFromContext(ctx context.Context) (traceID, spanID string) {
+	ids, _ := ctx.Value(traceKey{}).(traceIDs)
+	return ids.traceID, ids.spanID
+}
+
+// WithTrace records the trace and span the failure belongs to.
+func WithTrace(traceID, spanID string) Option {
+	return func(o *errorOptions) {
+		o.err.TraceID = traceID
+		o.err.SpanID = spanID
+	}
+}
+
+// NewErrorFromContext is NewError with TraceID and SpanID taken from ctx.
+// Later options, including an explicit WithTrace, take precedence.
+func NewErrorFromContext(ctx context.Context, op, table string, cause error, opts ...Option) *DatabaseError {
+	traceID, spanID := TraceFromContext(ctx)
+	return NewError(op, table, cause, append([]Option{WithTrace(traceID, spanID)}, opts...)...)
+}
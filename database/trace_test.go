@@ -0,0 +1,56 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewErrorFromContext(t *testing.T) {
+	ctx := ContextWithTrace(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7")
+
+	err := NewErrorFromContext(ctx, "SELECT", "users", errors.New("i/o timeout"), WithKind(KindTimeout))
+
+	if err.TraceID != "4bf92f3577b34da6a3ce929d0e0e4736" || err.SpanID != "00f067aa0ba902b7" {
+		t.Errorf("TraceID = %q, SpanID = %q; want the context's IDs", err.TraceID, err.SpanID)
+	}
+	if !err.Retryable {
+		t.Error("NewErrorFromContext should still apply the other options")
+	}
+
+	msg := err.Error()
+	for _, want := range []string{"trace: 4bf92f3577b34da6a3ce929d0e0e4736", "span: 00f067aa0ba902b7"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("Error() = %q; want it to contain %q", msg, want)
+		}
+	}
+
+	data, jsonErr := json.Marshal(err)
+	if jsonErr != nil {
+		t.Fatalf("json.Marshal() error = %v", jsonErr)
+	}
+	if !strings.Contains(string(data), "4bf92f3577b34da6a3ce929d0e0e4736") {
+		t.Errorf("JSON = %s; want the trace ID", data)
+	}
+}
+
+func TestNewErrorFromContext_ExplicitTraceWins(t *testing.T) {
+	ctx := ContextWithTrace(context.Background(), "from-context", "span-a")
+
+	err := NewErrorFromContext(ctx, "SELECT", "users", nil, WithTrace("explicit", "span-b"))
+	if err.TraceID != "explicit" || err.SpanID != "span-b" {
+		t.Errorf("TraceID = %q, SpanID = %q; want the explicit IDs", err.TraceID, err.SpanID)
+	}
+}
+
+func TestNewErrorFromContext_NoTrace(t *testing.T) {
+	err := NewErrorFromContext(context.Background(), "SELECT", "users", errors.New("boom"))
+	if err.TraceID != "" || err.SpanID != "" {
+		t.Errorf("TraceID = %q, SpanID = %q; want empty", err.TraceID, err.SpanID)
+	}
+	if strings.Contains(err.Error(), "trace:") {
+		t.Errorf("Error() = %q; want no trace section", err.Error())
+	}
+}
@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+// TxBeginner is implemented by *sql.DB and *sql.Conn.
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+// TxRetryPolicy governs how often WithTx reruns a transaction that failed
+// with a retryable error such as a classified deadlock.
+var TxRetryPolicy = RetryPolicy{MaxAttempts: 3}
+
+// Transaction phases reported by TxError.
+const (
+	TxPhaseBegin  = "begin"
+	TxPhaseExec   = "exec"
+	TxPhaseCommit = "commit"
+)
+
+// TxError reports a failed transaction attempt. When the rollback after a
+// failure also fails, Err is errors.Join(opErr, rollbackErr) and
+// RollbackErr holds the rollback failure, so neither is silently lost.
+type TxError struct {
+	Phase       string
+	Attempt     int
+	Err         error
+	RollbackErr error
+}
+
+func (e *TxError) Error() string {
+	return fmt.Sprintf("transaction %s failed on attempt %d: %v", e.Phase, e.Attempt, e.Err)
+}
+
+func (e *TxError) Unwrap() error {
+	return e.Err
+}
+
+// WithTx runs fn in a transaction, committing when it returns nil and
+// rolling back otherwise. Failures come back as *TxError; retryable ones
+// rerun the whole transaction according to TxRetryPolicy. A panic in fn
+// rolls back before propagating.
+func WithTx(ctx context.Context, db TxBeginner, fn func(tx *sql.Tx) error) error {
+	attempt := 0
+	return Retry(ctx, TxRetryPolicy, func() error {
+		attempt++
+		return runTx(ctx, db, attempt, fn)
+	})
+}
+
+func runTx(ctx context.Context, db TxBeginner, attempt int, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return &TxError{Phase: TxPhaseBegin, Attempt: attempt, Err: err}
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if opErr := fn(tx); opErr != nil {
+		txErr := &TxError{Phase: TxPhaseExec, Attempt: attempt, Err: opErr}
+		if rbErr := tx.Rollback(); rbErr != nil && !errors.Is(rbErr, sql.ErrTxDone) {
+			txErr.RollbackErr = rbErr
+			txErr.Err = errors.Join(opErr, rbErr)
+		}
+		return txErr
+	}
+
+	if err := tx.Commit(); err != nil {
+		return &TxError{Phase: TxPhaseCommit, Attempt: attempt, Err: err}
+	}
+	return nil
+}
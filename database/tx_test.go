@@ -0,0 +1,180 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+)
+
+// fakeConn is a minimal driver connection that only supports transactions.
+type fakeConn struct {
+	beginErr, commitErr, rollbackErr error
+	commits, rollbacks               int
+}
+
+func (c *fakeConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c *fakeConn) Close() error                        { return nil }
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	if c.beginErr != nil {
+		return nil, c.beginErr
+	}
+	return fakeTx{c}, nil
+}
+
+type fakeTx struct{ c *fakeConn }
+
+func (t fakeTx) Commit() error {
+	t.c.commits++
+	return t.c.commitErr
+}
+
+func (t fakeTx) Rollback() error {
+	t.c.rollbacks++
+	return t.c.rollbackErr
+}
+
+type fakeConnector struct{ conn *fakeConn }
+
+func (f fakeConnector) Connect(context.Context) (driver.Conn, error) { return f.conn, nil }
+func (f fakeConnector) Driver() driver.Driver                        { return nil }
+
+func openFakeDB(t *testing.T, conn *fakeConn) *sql.DB {
+	t.Helper()
+	db := sql.OpenDB(fakeConnector{conn})
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestWithTx_Commits(t *testing.T) {
+	conn := &fakeConn{}
+	db := openFakeDB(t, conn)
+
+	if err := WithTx(context.Background(), db, func(*sql.Tx) error { return nil }); err != nil {
+		t.Fatalf("WithTx() = %v; want nil", err)
+	}
+	if conn.commits != 1 || conn.rollbacks != 0 {
+		t.Errorf("commits = %d, rollbacks = %d; want 1, 0", conn.commits, conn.rollbacks)
+	}
+}
+
+func TestWithTx_RollsBackOnError(t *testing.T) {
+	conn := &fakeConn{}
+	db := openFakeDB(t, conn)
+	opErr := errors.New("insert failed")
+
+	err := WithTx(context.Background(), db, func(*sql.Tx) error { return opErr })
+
+	var txErr *TxError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("WithTx() = %v; want TxError", err)
+	}
+	if txErr.Phase != TxPhaseExec || txErr.Attempt != 1 || txErr.RollbackErr != nil {
+		t.Errorf("TxError = %+v; want exec phase on attempt 1 without rollback error", txErr)
+	}
+	if err != txErr || !errors.Is(err, opErr) {
+		t.Errorf("WithTx() = %v; want a TxError wrapping the operation error", err)
+	}
+	if conn.commits != 0 || conn.rollbacks != 1 {
+		t.Errorf("commits = %d, rollbacks = %d; want 0, 1", conn.commits, conn.rollbacks)
+	}
+}
+
+func TestWithTx_JoinsRollbackError(t *testing.T) {
+	rollbackErr := errors.New("connection lost during rollback")
+	conn := &fakeConn{rollbackErr: rollbackErr}
+	db := openFakeDB(t, conn)
+	opErr := errors.New("insert failed")
+
+	err := WithTx(context.Background(), db, func(*sql.Tx) error { return opErr })
+
+	var txErr *TxError
+	if !errors.As(err, &txErr) {
+		t.Fatalf("WithTx() = %v; want TxError", err)
+	}
+	if !errors.Is(err, opErr) || !errors.Is(err, rollbackErr) {
+		t.Errorf("WithTx() = %v; want both the operation and rollback errors", err)
+	}
+	if txErr.RollbackErr != rollbackErr {
+		t.Errorf("RollbackErr = %v; want %v", txErr.RollbackErr, rollbackErr)
+	}
+}
+
+func TestWithTx_BeginAndCommitFailures(t *testing.T) {
+	tests := []struct {
+		name  string
+		conn  *fakeConn
+		phase string
+	}{
+		{"begin", &fakeConn{beginErr: errors.New("too many connections")}, TxPhaseBegin},
+		{"commit", &fakeConn{commitErr: errors.New("serialization failure")}, TxPhaseCommit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := WithTx(context.Background(), openFakeDB(t, tt.conn), func(*sql.Tx) error { return nil })
+
+			var txErr *TxError
+			if !errors.As(err, &txErr) || txErr.Phase != tt.phase {
+				t.Errorf("WithTx() = %v; want TxError in phase %s", err, tt.phase)
+			}
+		})
+	}
+}
+
+func TestWithTx_RetriesRetryableFailures(t *testing.T) {
+	defer SetClock(newFakeClock())()
+
+	conn := &fakeConn{}
+	db := openFakeDB(t, conn)
+
+	calls := 0
+	err := WithTx(context.Background(), db, func(*sql.Tx) error {
+		calls++
+		if calls < 2 {
+			return Classify(errors.New("deadlock detected"), "UPDATE", "accounts")
+		}
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("WithTx() = %v; want success on the second attempt", err)
+	}
+	if calls != 2 || conn.rollbacks != 1 || conn.commits != 1 {
+		t.Errorf("calls = %d, rollbacks = %d, commits = %d; want 2, 1, 1", calls, conn.rollbacks, conn.commits)
+	}
+}
+
+func TestWithTx_ReportsLastAttempt(t *testing.T) {
+	defer SetClock(newFakeClock())()
+
+	db := openFakeDB(t, &fakeConn{})
+	err := WithTx(context.Background(), db, func(*sql.Tx) error {
+		return Classify(errors.New("deadlock detected"), "UPDATE", "accounts")
+	})
+
+	var txErr *TxError
+	if !errors.Is(err, ErrRetryAttemptsExhausted) || !errors.As(err, &txErr) {
+		t.Fatalf("WithTx() = %v; want exhausted retries wrapping a TxError", err)
+	}
+	if txErr.Attempt != TxRetryPolicy.MaxAttempts {
+		t.Errorf("TxError.Attempt = %d; want %d", txErr.Attempt, TxRetryPolicy.MaxAttempts)
+	}
+}
+
+func TestWithTx_RollsBackOnPanic(t *testing.T) {
+	conn := &fakeConn{}
+	db := openFakeDB(t, conn)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("WithTx should re-panic")
+		}
+		if conn.rollbacks != 1 {
+			t.Errorf("rollbacks = %d; want 1", conn.rollbacks)
+		}
+	}()
+	_ = WithTx(context.Background(), db, func(*sql.Tx) error { panic("boom") })
+}
@@ -1,6 +1,7 @@
 package example
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"go-error-handling/basic"
@@ -12,8 +13,18 @@ import (
 	"go-error-handling/wrapping"
 	"log"
 	"os"
+	"path/filepath"
+	"time"
 )
 
+// codes is the example domain, 1000-1999.
+var codes = custom.MustDomain("example", 1000)
+
+func init() {
+	codes.MustRegister(custom.CodeNegative, "value is negative")
+	codes.MustRegister(custom.CodeTooLarge, "value exceeds the maximum")
+}
+
 // Example 1.1: Simple error creation and checking
 func BasicErrorExample() {
 	result, err := basic.Divide(10, 0)
@@ -30,7 +41,7 @@ func CustomErrorExample(value int) error {
 		return &custom.ValidationError{
 			Field:   "value",
 			Message: "Value cannot be negative",
-			Code:    1001,
+			Code:    codes.Code(custom.CodeNegative),
 			Value:   value,
 		}
 	}
@@ -38,7 +49,7 @@ func CustomErrorExample(value int) error {
 		return &custom.ValidationError{
 			Field:   "value",
 			Message: "Value cannot be greater than 100",
-			Code:    1002,
+			Code:    codes.Code(custom.CodeTooLarge),
 			Value:   value,
 		}
 	}
@@ -60,6 +71,10 @@ func WrappingErrorExample(filename string) {
 	err := wrapping.ProcessUserData(123)
 	if err != nil {
 		log.Printf("Full error chain: %v\n", err)
+		log.Printf("Error levels:\n%s\n", wrapping.Format(err, wrapping.FormatOptions{Fields: true}))
+		if trace := wrapping.StackTrace(err); len(trace) > 0 {
+			log.Printf("Failed in %s (%s:%d)\n", trace[0].Function, filepath.Base(trace[0].File), trace[0].Line)
+		}
 
 		// Check if it wraps a specific error
 		if errors.Is(err, os.ErrNotExist) {
@@ -92,8 +107,125 @@ func ComplexErrorExample() {
 			log.Printf("Retryable: %v\n", dbErr.Retryable)
 
 			if dbErr.Retryable {
-				log.Println("Retrying operation...")
+				policy := database.RetryPolicy{
+					MaxAttempts: 3,
+					Jitter:      0.2,
+					OnRetry: func(attempt int, err error, next time.Duration) {
+						log.Printf("Attempt %d failed, retrying in %v: %v\n", attempt, next, err)
+					},
+				}
+				err = database.Retry(context.Background(), policy, func() error {
+					return user.QueryUsers(10)
+				})
+				if err != nil {
+					log.Printf("Giving up: %v\n", err)
+				}
+			}
+		}
+	}
+}
+
+// Example 5.2: Backpressure when the connection pool is saturated
+func PoolExhaustionExample() {
+	pool := database.NewPool(1, 10*time.Millisecond)
+	release, err := pool.Acquire(context.Background())
+	if err != nil {
+		log.Printf("Error: %v\n", err)
+		return
+	}
+	defer release()
+
+	// A second caller finds the only connection busy
+	if _, err := pool.Acquire(context.Background()); err != nil {
+		var exhausted *database.PoolExhaustedError
+		if errors.As(err, &exhausted) {
+			log.Printf("Pool saturated (%d/%d in use, waited %v) - shedding request\n",
+				exhausted.InUse, exhausted.Size, exhausted.Waited)
+			return
+		}
+		log.Printf("Unexpected error: %v\n", err)
+	}
+}
+
+// Example 5.3: Parking poison operations in a dead letter store
+func DeadLetterExample() {
+	deadLetter := database.NewMemoryDeadLetter()
+	policy := database.RetryPolicy{MaxAttempts: 3, DeadLetter: deadLetter}
+
+	emails := []string{"ana@example.com", "dup@example.com", "li@example.com"}
+	for _, email := range emails {
+		err := database.Retry(context.Background(), policy, func() error {
+			if email == "dup@example.com" {
+				return database.Classify(errors.New("duplicate key value violates unique constraint"), "INSERT", "users")
 			}
+			return nil
+		})
+		if err != nil {
+			log.Printf("Skipping %s: %v\n", email, err)
+		}
+	}
+
+	for _, entry := range deadLetter.Drain() {
+		log.Printf("Parked %s on %s at %s: %v\n",
+			entry.Operation, entry.Table, entry.Timestamp.Format(time.RFC3339), entry.Err)
+	}
+}
+
+// Example 5.4: Intermittent failures against a fault-injecting fake store
+func FaultInjectionExample() {
+	original := user.Store
+	defer func() { user.Store = original }()
+
+	user.Store = database.NewFake().
+		Insert("users", user.User{ID: 1, Email: "ana@example.com", Age: 30}).
+		FailEvery(2, database.KindDeadlock)
+
+	policy := database.RetryPolicy{
+		MaxAttempts: 3,
+		OnRetry: func(attempt int, err error, next time.Duration) {
+			log.Printf("Attempt %d hit %v, retrying in %v\n", attempt, database.KindOf(err), next)
+		},
+	}
+	for i := 0; i < 2; i++ {
+		var users []user.User
+		err := database.Retry(context.Background(), policy, func() error {
+			var err error
+			users, err = user.ListUsers(context.Background(), 10)
+			return err
+		})
+		if err != nil {
+			log.Printf("Error: %v\n", err)
+			continue
+		}
+		log.Printf("Loaded %d users\n", len(users))
+	}
+}
+
+// Example 6.1: A throttling-aware client that honors the server's reset time
+func RateLimitExample() {
+	quota := 1
+	search := func() error {
+		if quota == 0 {
+			return fmt.Errorf("search: %w", &utils.RateLimitError{Limit: 1, ResetAt: time.Now().Add(20 * time.Millisecond)})
+		}
+		quota--
+		return nil
+	}
+
+	for i := 1; i <= 2; i++ {
+		err := search()
+		var rateLimitErr *utils.RateLimitError
+		if errors.As(err, &rateLimitErr) {
+			wait := rateLimitErr.RetryAfter()
+			log.Printf("Request %d throttled (HTTP %d), retrying in %v\n", i, utils.HTTPStatus(err), wait.Round(time.Millisecond))
+			time.Sleep(wait)
+			quota = rateLimitErr.Limit
+			err = search()
+		}
+		if err != nil {
+			log.Printf("Error: %v\n", err)
+			continue
 		}
+		log.Printf("Request %d succeeded\n", i)
 	}
 }
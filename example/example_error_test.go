@@ -230,3 +230,43 @@ func TestAllExampleFunctions_Integration(t *testing.T) {
 	ComplexErrorExample()
 	CustomErrorExample(999)
 }
+
+func TestPoolExhaustionExample_DoesNotPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("PoolExhaustionExample() panicked: %v", r)
+		}
+	}()
+
+	PoolExhaustionExample()
+}
+
+func TestDeadLetterExample_DoesNotPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("DeadLetterExample() panicked: %v", r)
+		}
+	}()
+
+	DeadLetterExample()
+}
+
+func TestFaultInjectionExample_DoesNotPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("FaultInjectionExample() panicked: %v", r)
+		}
+	}()
+
+	FaultInjectionExample()
+}
+
+func TestRateLimitExample_DoesNotPanic(t *testing.T) {
+	defer func() {
+		if r := recover(); r != nil {
+			t.Errorf("RateLimitExample() panicked: %v", r)
+		}
+	}()
+
+	RateLimitExample()
+}
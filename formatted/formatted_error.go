@@ -2,14 +2,37 @@ package formatted
 
 import (
 	"fmt"
+	"go-error-handling/custom"
 )
 
+// codes is the formatted domain, 3000-3999.
+var codes = custom.MustDomain("formatted", 3000)
+
+func init() {
+	codes.MustRegister(custom.CodeNegative, "age is negative")
+	codes.MustRegister(custom.CodeTooLarge, "age exceeds the maximum")
+	custom.MustRegisterMessageTemplate(codes.Code(custom.CodeNegative), "{{.Field}} cannot be negative")
+}
+
 func ValidateAge(age int) error {
-	if age < 0 {
-		return fmt.Errorf("invalid age: %d. Age cannot be negative", age)
-	}
-	if age > 130 {
-		return fmt.Errorf("invalid age: %d. Age cannot be greater than 130", age)
+	if ve := custom.ValidateBounds("Age", age, 0, 130, codes.Code(custom.CodeNegative), codes.Code(custom.CodeTooLarge)); ve != nil {
+		return fmt.Errorf("invalid age: %d. %s", age, ve.Message)
 	}
 	return nil
 }
+
+// ValidateAgeWithWarnings behaves like ValidateAge but also flags ages that
+// are valid yet sit exactly on a boundary, which often indicates a default
+// or placeholder value rather than real data.
+func ValidateAgeWithWarnings(age int) (err error, warnings []string) {
+	if err := ValidateAge(age); err != nil {
+		return err, nil
+	}
+	switch age {
+	case 0:
+		warnings = append(warnings, fmt.Sprintf("age %d is at the minimum boundary", age))
+	case 130:
+		warnings = append(warnings, fmt.Sprintf("age %d is at the maximum boundary", age))
+	}
+	return nil, warnings
+}
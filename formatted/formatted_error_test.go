@@ -2,6 +2,7 @@ package formatted
 
 import (
 	"fmt"
+	"go-error-handling/internal/errtest"
 	"strings"
 	"testing"
 )
@@ -124,3 +125,47 @@ func TestValidateAge_ErrorMessages(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateAgeWithWarnings(t *testing.T) {
+	tests := []struct {
+		name             string
+		age              int
+		expectError      bool
+		expectedWarnings int
+	}{
+		{"typical age", 25, false, 0},
+		{"minimum boundary", 0, false, 1},
+		{"maximum boundary", 130, false, 1},
+		{"negative age", -5, true, 0},
+		{"too old age", 131, true, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err, warnings := ValidateAgeWithWarnings(tt.age)
+
+			if tt.expectError && err == nil {
+				t.Errorf("ValidateAgeWithWarnings(%d) expected error but got none", tt.age)
+			}
+			if !tt.expectError && err != nil {
+				t.Errorf("ValidateAgeWithWarnings(%d) returned unexpected error: %v", tt.age, err)
+			}
+			if len(warnings) != tt.expectedWarnings {
+				t.Errorf("ValidateAgeWithWarnings(%d) returned %d warnings; want %d: %v", tt.age, len(warnings), tt.expectedWarnings, warnings)
+			}
+
+			ageStr := fmt.Sprintf("%d", tt.age)
+			for _, warning := range warnings {
+				if !strings.Contains(warning, ageStr) {
+					t.Errorf("warning %q should mention age %d", warning, tt.age)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateAge_NoAllocOnSuccess(t *testing.T) {
+	errtest.AssertNoAllocOnSuccess(t, func() error {
+		return ValidateAge(25)
+	})
+}
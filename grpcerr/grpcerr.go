@@ -0,0 +1,246 @@
+// Package grpcerr converts this module's errors to and from gRPC statuses,
+// carrying field, code and retry metadata in standard google.rpc error
+// details so clients in any language can read them.
+//
+// Example usage:
+//
+//	func (s *server) CreateUser(ctx context.Context, req *pb.CreateUserRequest) (*pb.User, error) {
+//		if err := user.ValidateUser(u); err != nil {
+//			return nil, grpcerr.ToStatus(err).Err()
+//		}
+//		...
+//	}
+package grpcerr
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"go-error-handling/utils"
+	"strconv"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// Domain is the ErrorInfo domain attached to every status ToStatus builds.
+const Domain = "go-error-handling"
+
+// ReasonDatabaseError is the ErrorInfo reason for a DatabaseError. Sentinels
+// use their utils.Code name as the reason instead.
+const ReasonDatabaseError = "DATABASE_ERROR"
+
+// ReasonRateLimited is the ErrorInfo reason for a utils.RateLimitError.
+const ReasonRateLimited = "RATE_LIMITED"
+
+// sentinelCodes maps sentinels to the gRPC code they surface as.
+var sentinelCodes = []struct {
+	err  error
+	code codes.Code
+}{
+	{utils.ErrUserNotFound, codes.NotFound},
+	{utils.ErrUnauthorized, codes.Unauthenticated},
+	{utils.ErrInvalidPassword, codes.Unauthenticated},
+	{utils.ErrDuplicateEmail, codes.AlreadyExists},
+	{utils.ErrDatabaseTimeout, codes.DeadlineExceeded},
+	{utils.ErrValidation, codes.InvalidArgument},
+}
+
+// kindCodes maps database error kinds to gRPC codes. Kinds not listed are
+// Unavailable when retryable and Internal otherwise.
+var kindCodes = map[database.Kind]codes.Code{
+	database.KindNotFound:            codes.NotFound,
+	database.KindConstraintViolation: codes.FailedPrecondition,
+	database.KindDeadlock:            codes.Aborted,
+	database.KindConnection:          codes.Unavailable,
+	database.KindTimeout:             codes.DeadlineExceeded,
+	database.KindPermissionDenied:    codes.PermissionDenied,
+	database.KindPoolExhausted:       codes.ResourceExhausted,
+	database.KindCanceled:            codes.Canceled,
+}
+
+// ToStatus converts err into a gRPC status. Validation failures become
+// InvalidArgument with a BadRequest detail listing every field, database
+// errors carry an ErrorInfo and, when RetryAfter is set, a RetryInfo, rate
+// limit errors become ResourceExhausted with the same pair of details, and
+// sentinels carry an ErrorInfo whose reason is their utils.Code. Errors that
+// already wrap a status are returned as is; anything else becomes a generic
+// Internal status without leaking its message. It returns nil for nil.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	if ves := custom.AllValidationErrors(err); len(ves) > 0 {
+		violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(ves))
+		for _, ve := range ves {
+			violations = append(violations, &errdetails.BadRequest_FieldViolation{
+				Field:       ve.Field,
+				Description: ve.ResolvedMessage(),
+				Reason:      strconv.Itoa(ve.Code),
+			})
+		}
+		return withDetails(status.New(codes.InvalidArgument, utils.ErrValidation.Error()),
+			&errdetails.BadRequest{FieldViolations: violations})
+	}
+
+	var dbErr *database.DatabaseError
+	if errors.As(err, &dbErr) {
+		return databaseStatus(dbErr)
+	}
+
+	var rateLimitErr *utils.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return rateLimitStatus(rateLimitErr)
+	}
+
+	for _, sc := range sentinelCodes {
+		if errors.Is(err, sc.err) {
+			return withDetails(status.New(sc.code, sc.err.Error()), &errdetails.ErrorInfo{
+				Reason: utils.CodeOf(sc.err).String(),
+				Domain: Domain,
+			})
+		}
+	}
+
+	return status.New(codes.Internal, "internal error")
+}
+
+func rateLimitStatus(e *utils.RateLimitError) *status.Status {
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason: ReasonRateLimited,
+		Domain: Domain,
+		Metadata: map[string]string{
+			"limit":     strconv.Itoa(e.Limit),
+			"remaining": strconv.Itoa(e.Remaining),
+			"reset_at":  e.ResetAt.Format(time.RFC3339),
+		},
+	}}
+	if after := e.RetryAfter(); after > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(after)})
+	}
+	return withDetails(status.New(codes.ResourceExhausted, "rate limit exceeded"), details...)
+}
+
+func databaseStatus(e *database.DatabaseError) *status.Status {
+	code, ok := kindCodes[e.Kind]
+	if !ok {
+		code = codes.Internal
+		if database.IsRetryable(e) {
+			code = codes.Unavailable
+		}
+	}
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{
+		Reason: ReasonDatabaseError,
+		Domain: Domain,
+		Metadata: map[string]string{
+			"operation": e.Operation,
+			"table":     e.Table,
+			"kind":      e.Kind.String(),
+			"retryable": strconv.FormatBool(database.IsRetryable(e)),
+		},
+	}}
+	if e.RetryAfter > 0 {
+		details = append(details, &errdetails.RetryInfo{RetryDelay: durationpb.New(e.RetryAfter)})
+	}
+	msg := fmt.Sprintf("%s on %s failed", e.Operation, e.Table)
+	return withDetails(status.New(code, msg), details...)
+}
+
+// withDetails attaches details to st, falling back to st alone if they
+// cannot be encoded.
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		return withDetails
+	}
+	return st
+}
+
+// FromStatus converts a status received from another service back into this
+// module's errors, reversing ToStatus: BadRequest details become
+// ValidationErrors, a DATABASE_ERROR ErrorInfo becomes a *DatabaseError, a
+// RATE_LIMITED one a *utils.RateLimitError, and a sentinel reason wraps
+// that sentinel. Other statuses are returned as
+// st.Err(). It returns nil for a nil or OK status.
+func FromStatus(st *status.Status) error {
+	if st == nil || st.Code() == codes.OK {
+		return nil
+	}
+
+	var (
+		info      *errdetails.ErrorInfo
+		retryInfo *errdetails.RetryInfo
+	)
+	for _, detail := range st.Details() {
+		switch d := detail.(type) {
+		case *errdetails.BadRequest:
+			return validationErrors(d)
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.RetryInfo:
+			retryInfo = d
+		}
+	}
+
+	if info != nil && info.Domain == Domain {
+		if info.Reason == ReasonDatabaseError {
+			meta := info.Metadata
+			retryable, _ := strconv.ParseBool(meta["retryable"])
+			opts := []database.Option{
+				database.WithKind(kindFromString(meta["kind"])),
+				database.WithRetryable(retryable),
+			}
+			if retryInfo != nil {
+				opts = append(opts, database.WithRetryAfter(retryInfo.GetRetryDelay().AsDuration()))
+			}
+			return database.NewError(meta["operation"], meta["table"], errors.New(st.Message()), opts...)
+		}
+		if info.Reason == ReasonRateLimited {
+			meta := info.Metadata
+			limit, _ := strconv.Atoi(meta["limit"])
+			remaining, _ := strconv.Atoi(meta["remaining"])
+			resetAt, _ := time.Parse(time.RFC3339, meta["reset_at"])
+			return &utils.RateLimitError{Limit: limit, Remaining: remaining, ResetAt: resetAt}
+		}
+		for _, sc := range sentinelCodes {
+			if utils.CodeOf(sc.err).String() == info.Reason {
+				return fmt.Errorf("remote returned %s: %w", st.Code(), sc.err)
+			}
+		}
+	}
+	return st.Err()
+}
+
+func validationErrors(br *errdetails.BadRequest) error {
+	errs := make(custom.ValidationErrors, 0, len(br.GetFieldViolations()))
+	for _, v := range br.GetFieldViolations() {
+		code, _ := strconv.Atoi(v.GetReason())
+		errs = append(errs, &custom.ValidationError{
+			Field:   v.GetField(),
+			Message: v.GetDescription(),
+			Code:    code,
+		})
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	return errs
+}
+
+// kindFromString reverses database.Kind.String, defaulting to KindUnknown.
+func kindFromString(s string) database.Kind {
+	for kind := range kindCodes {
+		if kind.String() == s {
+			return kind
+		}
+	}
+	return database.KindUnknown
+}
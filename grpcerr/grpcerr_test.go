@@ -0,0 +1,178 @@
+package grpcerr
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"go-error-handling/utils"
+	"testing"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestToStatus_Sentinels(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected codes.Code
+	}{
+		{utils.ErrUserNotFound, codes.NotFound},
+		{utils.ErrUnauthorized, codes.Unauthenticated},
+		{utils.ErrDuplicateEmail, codes.AlreadyExists},
+		{utils.ErrDatabaseTimeout, codes.DeadlineExceeded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			st := ToStatus(fmt.Errorf("handler: %w", tt.err))
+			if st.Code() != tt.expected {
+				t.Errorf("ToStatus(%v) code = %v; want %v", tt.err, st.Code(), tt.expected)
+			}
+			if back := FromStatus(st); !errors.Is(back, tt.err) {
+				t.Errorf("FromStatus(ToStatus(%v)) = %v; want it to match the sentinel", tt.err, back)
+			}
+		})
+	}
+}
+
+func TestToStatus_ValidationRoundTrip(t *testing.T) {
+	err := custom.ValidationErrors{
+		{Field: "Age", Message: "Age cannot be negative", Code: 2001, Value: -1},
+		{Field: "Email", Message: "Email cannot be empty", Code: 2003},
+	}
+
+	st := ToStatus(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("ToStatus() code = %v; want InvalidArgument", st.Code())
+	}
+
+	back := custom.AllValidationErrors(FromStatus(st))
+	if len(back) != 2 {
+		t.Fatalf("FromStatus() = %v; want both field errors", back)
+	}
+	for i, ve := range back {
+		if ve.Field != err[i].Field || ve.Message != err[i].Message || ve.Code != err[i].Code {
+			t.Errorf("FromStatus()[%d] = %+v; want field, message and code of %+v", i, ve, err[i])
+		}
+	}
+}
+
+func TestToStatus_DatabaseRoundTrip(t *testing.T) {
+	dbErr := &database.DatabaseError{
+		Operation:  "SELECT",
+		Table:      "users",
+		Kind:       database.KindTimeout,
+		Retryable:  true,
+		RetryAfter: time.Second,
+		Err:        errors.New("i/o timeout on 10.0.0.5"),
+	}
+
+	st := ToStatus(fmt.Errorf("list users: %w", dbErr))
+	if st.Code() != codes.DeadlineExceeded {
+		t.Errorf("ToStatus() code = %v; want DeadlineExceeded", st.Code())
+	}
+	if st.Message() != "SELECT on users failed" {
+		t.Errorf("ToStatus() message = %q; want the cause left out", st.Message())
+	}
+
+	var back *database.DatabaseError
+	if !errors.As(FromStatus(st), &back) {
+		t.Fatalf("FromStatus() = %v; want a DatabaseError", FromStatus(st))
+	}
+	if back.Operation != "SELECT" || back.Table != "users" || back.Kind != database.KindTimeout ||
+		!back.Retryable || back.RetryAfter != time.Second {
+		t.Errorf("FromStatus() = %+v; want the original metadata", back)
+	}
+
+	at := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	defer database.SetClock(database.FrozenClock{At: at})()
+	if errors.As(FromStatus(st), &back); !back.Timestamp.Equal(at) {
+		t.Errorf("FromStatus() Timestamp = %v; want it stamped by the database clock", back.Timestamp)
+	}
+}
+
+func TestToStatus_DatabaseKinds(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      *database.DatabaseError
+		expected codes.Code
+	}{
+		{"constraint", &database.DatabaseError{Kind: database.KindConstraintViolation}, codes.FailedPrecondition},
+		{"deadlock", &database.DatabaseError{Kind: database.KindDeadlock, Retryable: true}, codes.Aborted},
+		{"retryable unknown", &database.DatabaseError{Retryable: true}, codes.Unavailable},
+		{"permanent unknown", &database.DatabaseError{}, codes.Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToStatus(tt.err).Code(); got != tt.expected {
+				t.Errorf("ToStatus() code = %v; want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestToStatus_RateLimit(t *testing.T) {
+	resetAt := time.Now().Add(time.Minute).Truncate(time.Second)
+	tests := []struct {
+		name          string
+		err           error
+		expectedRetry bool
+	}{
+		{"bare", &utils.RateLimitError{Limit: 100, ResetAt: resetAt}, true},
+		{"wrapped", fmt.Errorf("search: %w", &utils.RateLimitError{Limit: 100, Remaining: 0, ResetAt: resetAt}), true},
+		{"already reset", &utils.RateLimitError{Limit: 5, Remaining: 5, ResetAt: resetAt.Add(-time.Hour)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := ToStatus(tt.err)
+			if st.Code() != codes.ResourceExhausted {
+				t.Errorf("ToStatus() code = %v; want ResourceExhausted", st.Code())
+			}
+			hasRetry := false
+			for _, detail := range st.Details() {
+				if _, ok := detail.(*errdetails.RetryInfo); ok {
+					hasRetry = true
+				}
+			}
+			if hasRetry != tt.expectedRetry {
+				t.Errorf("ToStatus() RetryInfo present = %v; want %v", hasRetry, tt.expectedRetry)
+			}
+
+			var want, back *utils.RateLimitError
+			errors.As(tt.err, &want)
+			if !errors.As(FromStatus(st), &back) {
+				t.Fatalf("FromStatus() = %v; want a RateLimitError", FromStatus(st))
+			}
+			if back.Limit != want.Limit || back.Remaining != want.Remaining || !back.ResetAt.Equal(want.ResetAt) {
+				t.Errorf("FromStatus() = %+v; want %+v", back, want)
+			}
+		})
+	}
+}
+
+func TestToStatus_Passthrough(t *testing.T) {
+	if ToStatus(nil) != nil {
+		t.Error("ToStatus(nil) should be nil")
+	}
+	if FromStatus(nil) != nil || FromStatus(status.New(codes.OK, "")) != nil {
+		t.Error("FromStatus of nil or OK should be nil")
+	}
+
+	remote := status.Error(codes.ResourceExhausted, "quota exceeded")
+	if got := ToStatus(fmt.Errorf("upload: %w", remote)); got.Code() != codes.ResourceExhausted {
+		t.Errorf("ToStatus(wrapped status) code = %v; want ResourceExhausted", got.Code())
+	}
+
+	st := ToStatus(errors.New("secret connection string"))
+	if st.Code() != codes.Internal || st.Message() != "internal error" {
+		t.Errorf("ToStatus(unknown) = %v; want a generic Internal status", st)
+	}
+	if err := FromStatus(status.New(codes.Unavailable, "down")); status.Code(err) != codes.Unavailable {
+		t.Errorf("FromStatus(plain) = %v; want the status error", err)
+	}
+}
@@ -0,0 +1,28 @@
+// Package errtest holds test helpers shared across the example packages.
+package errtest
+
+import "testing"
+
+// AssertNoAllocOnSuccess fails t unless fn succeeds without allocating.
+// Validators run on every request, so the happy path must stay free of
+// error construction costs; only failures should pay for building errors.
+func AssertNoAllocOnSuccess(t testing.TB, fn func() error) {
+	t.Helper()
+	if err := fn(); err != nil {
+		t.Fatalf("AssertNoAllocOnSuccess: fn returned error %v; want nil", err)
+		return
+	}
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = fn()
+	})
+	if allocs != 0 {
+		t.Errorf("AssertNoAllocOnSuccess: fn allocated %v times per run; want 0", allocs)
+	}
+}
+
+// LoopError unwraps to Next, which tests point back at the LoopError,
+// directly or through other wrappers, to build a chain that wraps itself.
+type LoopError struct{ Next error }
+
+func (e *LoopError) Error() string { return "loop" }
+func (e *LoopError) Unwrap() error { return e.Next }
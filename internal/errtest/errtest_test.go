@@ -0,0 +1,59 @@
+package errtest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// recordingTB captures failures so the helper's own failure paths can be
+// asserted without failing this test.
+type recordingTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func (r *recordingTB) Fatalf(format string, args ...any) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+var sink []byte
+
+func TestAssertNoAllocOnSuccess_Passes(t *testing.T) {
+	rec := &recordingTB{TB: t}
+
+	AssertNoAllocOnSuccess(rec, func() error { return nil })
+
+	if len(rec.failures) != 0 {
+		t.Errorf("AssertNoAllocOnSuccess reported failures for a non-allocating fn: %v", rec.failures)
+	}
+}
+
+func TestAssertNoAllocOnSuccess_DetectsAllocation(t *testing.T) {
+	rec := &recordingTB{TB: t}
+
+	AssertNoAllocOnSuccess(rec, func() error {
+		sink = make([]byte, 64)
+		return nil
+	})
+
+	if len(rec.failures) != 1 {
+		t.Errorf("AssertNoAllocOnSuccess should report one failure for an allocating fn, got %v", rec.failures)
+	}
+}
+
+func TestAssertNoAllocOnSuccess_DetectsError(t *testing.T) {
+	rec := &recordingTB{TB: t}
+
+	AssertNoAllocOnSuccess(rec, func() error { return errors.New("boom") })
+
+	if len(rec.failures) != 1 {
+		t.Errorf("AssertNoAllocOnSuccess should report one failure for a failing fn, got %v", rec.failures)
+	}
+}
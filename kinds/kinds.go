@@ -0,0 +1,144 @@
+// Package kinds sorts every error this module produces, along with standard
+// library os and context errors, into a small set of cross-cutting kinds, so
+// handlers, transports and metrics can treat failures uniformly without
+// knowing each error type.
+//
+// Example usage:
+//
+//	switch kinds.KindOf(err) {
+//	case kinds.NotFound:
+//		// render 404
+//	case kinds.Unavailable, kinds.DeadlineExceeded:
+//		// retry later
+//	}
+package kinds
+
+import (
+	"context"
+	"errors"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"go-error-handling/utils"
+	"io/fs"
+	"os"
+)
+
+// Kind is the cross-cutting class of an error.
+type Kind int
+
+const (
+	// Unknown is the Kind of a nil error.
+	Unknown Kind = iota
+	NotFound
+	InvalidArgument
+	AlreadyExists
+	PermissionDenied
+	Unavailable
+	Internal
+	DeadlineExceeded
+	Canceled
+	// ResourceExhausted means the caller used up a quota, as reported by a
+	// utils.RateLimitError, and should back off before trying again.
+	ResourceExhausted
+)
+
+func (k Kind) String() string {
+	switch k {
+	case NotFound:
+		return "not found"
+	case InvalidArgument:
+		return "invalid argument"
+	case AlreadyExists:
+		return "already exists"
+	case PermissionDenied:
+		return "permission denied"
+	case Unavailable:
+		return "unavailable"
+	case Internal:
+		return "internal"
+	case DeadlineExceeded:
+		return "deadline exceeded"
+	case Canceled:
+		return "canceled"
+	case ResourceExhausted:
+		return "resource exhausted"
+	}
+	return "unknown"
+}
+
+// databaseKinds maps database error kinds. Constraint violations are
+// AlreadyExists for unique keys and InvalidArgument otherwise, and
+// KindUnknown depends on retryability, so neither is listed.
+var databaseKinds = map[database.Kind]Kind{
+	database.KindNotFound:         NotFound,
+	database.KindDeadlock:         Unavailable,
+	database.KindConnection:       Unavailable,
+	database.KindTimeout:          DeadlineExceeded,
+	database.KindPermissionDenied: PermissionDenied,
+	database.KindPoolExhausted:    Unavailable,
+	database.KindCanceled:         Canceled,
+}
+
+// targetKinds maps sentinels and standard library errors, matched with
+// errors.Is in order.
+var targetKinds = []struct {
+	target error
+	kind   Kind
+}{
+	{utils.ErrValidation, InvalidArgument},
+	{utils.ErrUserNotFound, NotFound},
+	{utils.ErrDuplicateEmail, AlreadyExists},
+	{utils.ErrInvalidPassword, PermissionDenied},
+	{utils.ErrUnauthorized, PermissionDenied},
+	{utils.ErrDatabaseTimeout, DeadlineExceeded},
+	{context.DeadlineExceeded, DeadlineExceeded},
+	{context.Canceled, Canceled},
+	{os.ErrDeadlineExceeded, DeadlineExceeded},
+	{fs.ErrNotExist, NotFound},
+	{fs.ErrExist, AlreadyExists},
+	{fs.ErrPermission, PermissionDenied},
+	{fs.ErrInvalid, InvalidArgument},
+}
+
+// KindOf classifies err. Validation errors are InvalidArgument, rate limit
+// errors ResourceExhausted, database errors follow their database.Kind,
+// and sentinels and os and context errors
+// map to their natural kind. A database error of unknown kind is
+// Unavailable when retryable; everything else unrecognized is Internal.
+// KindOf returns Unknown for nil.
+func KindOf(err error) Kind {
+	if err == nil {
+		return Unknown
+	}
+	if len(custom.AllValidationErrors(err)) > 0 {
+		return InvalidArgument
+	}
+	if utils.IsRateLimited(err) {
+		return ResourceExhausted
+	}
+
+	var dbErr *database.DatabaseError
+	isDatabaseError := errors.As(err, &dbErr)
+	if isDatabaseError {
+		if kind, ok := databaseKinds[dbErr.Kind]; ok {
+			return kind
+		}
+		if dbErr.Kind == database.KindConstraintViolation {
+			var unique *database.UniqueConstraintError
+			if errors.As(err, &unique) {
+				return AlreadyExists
+			}
+			return InvalidArgument
+		}
+	}
+
+	for _, tk := range targetKinds {
+		if errors.Is(err, tk.target) {
+			return tk.kind
+		}
+	}
+	if isDatabaseError && database.IsRetryable(err) {
+		return Unavailable
+	}
+	return Internal
+}
@@ -0,0 +1,60 @@
+package kinds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"go-error-handling/utils"
+	"os"
+	"testing"
+)
+
+func TestKindOf(t *testing.T) {
+	_, notExist := os.Open("does-not-exist.txt")
+
+	tests := []struct {
+		name     string
+		err      error
+		expected Kind
+	}{
+		{"nil", nil, Unknown},
+		{"validation error", &custom.ValidationError{Field: "Age", Code: 2001}, InvalidArgument},
+		{"validation sentinel", fmt.Errorf("import: %w", utils.ErrValidation), InvalidArgument},
+		{"user not found", fmt.Errorf("find: %w", utils.ErrUserNotFound), NotFound},
+		{"duplicate email", utils.ErrDuplicateEmail, AlreadyExists},
+		{"unauthorized", utils.ErrUnauthorized, PermissionDenied},
+		{"database timeout sentinel", utils.ErrDatabaseTimeout, DeadlineExceeded},
+		{"database not found", &database.DatabaseError{Kind: database.KindNotFound}, NotFound},
+		{"database deadlock", &database.DatabaseError{Kind: database.KindDeadlock, Retryable: true}, Unavailable},
+		{"database timeout", &database.DatabaseError{Kind: database.KindTimeout}, DeadlineExceeded},
+		{"unique constraint", database.Classify(errors.New(`duplicate key value violates unique constraint "users_email_key"`), "INSERT", "users"), AlreadyExists},
+		{"foreign key", database.Classify(errors.New(`insert or update on table "orders" violates foreign key constraint "orders_user_id_fkey"`), "INSERT", "orders"), InvalidArgument},
+		{"retryable unknown database error", &database.DatabaseError{Retryable: true}, Unavailable},
+		{"permanent unknown database error", &database.DatabaseError{}, Internal},
+		{"tagged unknown database error", utils.Tag(&database.DatabaseError{}, utils.ErrUserNotFound), NotFound},
+		{"file not found", notExist, NotFound},
+		{"file exists", fmt.Errorf("create: %w", os.ErrExist), AlreadyExists},
+		{"file permission", os.ErrPermission, PermissionDenied},
+		{"context deadline", fmt.Errorf("fetch: %w", context.DeadlineExceeded), DeadlineExceeded},
+		{"context canceled", context.Canceled, Canceled},
+		{"rate limited", fmt.Errorf("search: %w", &utils.RateLimitError{Limit: 100}), ResourceExhausted},
+		{"rate limited over a sentinel", utils.Tag(&utils.RateLimitError{Limit: 100}, utils.ErrUnauthorized), ResourceExhausted},
+		{"unknown", errors.New("boom"), Internal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := KindOf(tt.err); got != tt.expected {
+				t.Errorf("KindOf(%v) = %v; want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	if NotFound.String() != "not found" || Kind(99).String() != "unknown" {
+		t.Errorf("String() = %q, %q; want not found, unknown", NotFound, Kind(99))
+	}
+}
@@ -1,22 +1,62 @@
 package main
 
 import (
+	"fmt"
 	"go-error-handling/example"
+	"go-error-handling/utils"
+	"os"
 )
 
-func main() {
-	example.BasicErrorExample()
+// examples are the steps main runs, in order. Examples that handle and log
+// their own errors report nil; the CustomErrorExample steps return their
+// validation errors.
+var examples = []func() error{
+	logged(example.BasicErrorExample),
+
+	func() error { return example.CustomErrorExample(-5) },
+	func() error { return example.CustomErrorExample(150) },
+
+	logged(func() { example.FormattedErrorExample(-10) }),
+	logged(func() { example.FormattedErrorExample(25) }),
+	logged(func() { example.FormattedErrorExample(150) }),
+
+	logged(func() { example.WrappingErrorExample("non_existent_file.txt") }),
+	logged(func() { example.WrappingErrorExample("valid_file.txt") }),
 
-	example.CustomErrorExample(-5)
-	example.CustomErrorExample(150)
+	logged(example.ComplexErrorExample),
+	logged(example.PoolExhaustionExample),
+	logged(example.DeadLetterExample),
+	logged(example.FaultInjectionExample),
+	logged(example.RateLimitExample),
+	func() error { return example.CustomErrorExample(999) },
+}
 
-	example.FormattedErrorExample(-10)
-	example.FormattedErrorExample(25)
-	example.FormattedErrorExample(150)
+func main() {
+	err := run(examples)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+	}
+	os.Exit(utils.ExitCode(err))
+}
 
-	example.WrappingErrorExample("non_existent_file.txt")
-	example.WrappingErrorExample("valid_file.txt")
+// run executes every step and returns the first error, which decides the
+// exit code (see utils.ExitCode), so scripts can tell failure classes apart.
+// The examples end in validation errors, so the demo exits with
+// utils.ExitValidation.
+func run(steps []func() error) error {
+	var first error
+	for _, step := range steps {
+		if err := step(); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
 
-	example.ComplexErrorExample()
-	example.CustomErrorExample(999)
+// logged adapts an example that logs its own errors into a step.
+func logged(example func()) func() error {
+	return func() error {
+		example()
+		return nil
+	}
 }
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/utils"
+	"testing"
+)
+
+func TestRun_ExitCode(t *testing.T) {
+	ok := func() error { return nil }
+	timeout := func() error { return fmt.Errorf("query: %w", utils.ErrDatabaseTimeout) }
+	notFound := func() error { return utils.ErrUserNotFound }
+
+	ran := 0
+	counted := func() error { ran++; return nil }
+
+	tests := []struct {
+		name     string
+		steps    []func() error
+		expected int
+	}{
+		{"all succeed", []func() error{ok, ok}, utils.ExitOK},
+		{"timeout", []func() error{ok, timeout, counted}, utils.ExitTimeout},
+		{"first error wins", []func() error{notFound, timeout}, utils.ExitNotFound},
+		{"internal", []func() error{func() error { return errors.New("boom") }}, utils.ExitInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := utils.ExitCode(run(tt.steps)); got != tt.expected {
+				t.Errorf("ExitCode(run()) = %d; want %d", got, tt.expected)
+			}
+		})
+	}
+	if ran != 1 {
+		t.Errorf("steps after a failure ran %d times; want 1, run should finish every step", ran)
+	}
+}
+
+func TestRun_Examples(t *testing.T) {
+	if got := utils.ExitCode(run(examples)); got != utils.ExitValidation {
+		t.Errorf("ExitCode(run(examples)) = %d; want %d for the demo's validation errors", got, utils.ExitValidation)
+	}
+}
@@ -0,0 +1,189 @@
+// Package problem renders errors as RFC 7807 "problem details" documents so
+// HTTP APIs return one consistent, machine-readable error shape.
+//
+// Example usage:
+//
+//	http.Handle("/users", problem.Handler(func(w http.ResponseWriter, r *http.Request) error {
+//		return user.ValidateUser(u)
+//	}))
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"go-error-handling/utils"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ContentType is the media type defined by RFC 7807 for JSON documents.
+const ContentType = "application/problem+json"
+
+// BaseURI prefixes the problem type identifiers.
+var BaseURI = "https://github.com/anwarul/go-error-handling/problems/"
+
+// Details is an RFC 7807 problem document. Extensions are emitted as
+// top-level members alongside the standard ones.
+type Details struct {
+	Type       string
+	Title      string
+	Status     int
+	Detail     string
+	Instance   string
+	Extensions map[string]any
+	// RetryAfter, when positive, is sent as the Retry-After header by Write
+	// rather than in the document.
+	RetryAfter time.Duration
+}
+
+func (d *Details) MarshalJSON() ([]byte, error) {
+	doc := make(map[string]any, len(d.Extensions)+5)
+	for k, v := range d.Extensions {
+		doc[k] = v
+	}
+	doc["type"] = d.Type
+	doc["title"] = d.Title
+	doc["status"] = d.Status
+	if d.Detail != "" {
+		doc["detail"] = d.Detail
+	}
+	if d.Instance != "" {
+		doc["instance"] = d.Instance
+	}
+	return json.Marshal(doc)
+}
+
+// sentinelProblems maps sentinels to the problem type they surface as.
+var sentinelProblems = []struct {
+	err  error
+	slug string
+}{
+	{utils.ErrUserNotFound, "user-not-found"},
+	{utils.ErrUnauthorized, "unauthorized"},
+	{utils.ErrInvalidPassword, "invalid-password"},
+	{utils.ErrDuplicateEmail, "duplicate-email"},
+	{utils.ErrDatabaseTimeout, "database-timeout"},
+	{utils.ErrValidation, "validation-error"},
+}
+
+// From converts err into a problem document. Validation failures take
+// precedence because they are the most actionable for clients; unknown
+// errors become a generic problem without leaking internal messages. The
+// status always comes from utils.HTTPStatus, so mappings added with
+// utils.RegisterHTTPStatus apply here too.
+func From(err error) *Details {
+	if err == nil {
+		return nil
+	}
+	status := utils.HTTPStatus(err)
+
+	if ves := custom.AllValidationErrors(err); len(ves) > 0 {
+		detail := ves[0].Message
+		if len(ves) > 1 {
+			detail = fmt.Sprintf("%d fields failed validation", len(ves))
+		}
+		return &Details{
+			Type:       BaseURI + "validation-error",
+			Title:      "Validation Failed",
+			Status:     status,
+			Detail:     detail,
+			Extensions: map[string]any{"errors": ves},
+		}
+	}
+
+	var dbErr *database.DatabaseError
+	if errors.As(err, &dbErr) {
+		return &Details{
+			Type:   BaseURI + "database-error",
+			Title:  http.StatusText(status),
+			Status: status,
+			Detail: fmt.Sprintf("%s on %s failed", dbErr.Operation, dbErr.Table),
+			Extensions: map[string]any{
+				"operation": dbErr.Operation,
+				"table":     dbErr.Table,
+				"retryable": database.IsRetryable(dbErr),
+			},
+		}
+	}
+
+	var permErr *utils.PermissionError
+	if errors.As(err, &permErr) {
+		return &Details{
+			Type:   BaseURI + "forbidden",
+			Title:  http.StatusText(status),
+			Status: status,
+			Detail: fmt.Sprintf("%s may not %s %s", permErr.Subject, permErr.Action, permErr.Resource),
+			Extensions: map[string]any{
+				"subject":  permErr.Subject,
+				"action":   permErr.Action,
+				"resource": permErr.Resource,
+			},
+		}
+	}
+
+	var rateLimitErr *utils.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		return &Details{
+			Type:   BaseURI + "rate-limited",
+			Title:  http.StatusText(status),
+			Status: status,
+			Detail: fmt.Sprintf("rate limit of %d requests exceeded", rateLimitErr.Limit),
+			Extensions: map[string]any{
+				"limit":     rateLimitErr.Limit,
+				"remaining": rateLimitErr.Remaining,
+				"reset_at":  rateLimitErr.ResetAt,
+			},
+			RetryAfter: rateLimitErr.RetryAfter(),
+		}
+	}
+
+	for _, sp := range sentinelProblems {
+		if errors.Is(err, sp.err) {
+			return &Details{
+				Type:   BaseURI + sp.slug,
+				Title:  http.StatusText(status),
+				Status: status,
+				Detail: sp.err.Error(),
+			}
+		}
+	}
+
+	return &Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+}
+
+// Write sends d as an application/problem+json response, with a
+// Retry-After header in whole seconds, rounded up, when d.RetryAfter is set.
+func Write(w http.ResponseWriter, d *Details) error {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("failed to encode problem details: %w", err)
+	}
+	w.Header().Set("Content-Type", ContentType)
+	if d.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.RetryAfter.Seconds()))))
+	}
+	w.WriteHeader(d.Status)
+	_, err = w.Write(body)
+	return err
+}
+
+// Handler adapts a handler that returns an error, rendering any error as a
+// problem document with the request path as its instance.
+func Handler(fn func(http.ResponseWriter, *http.Request) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := fn(w, r); err != nil {
+			d := From(err)
+			d.Instance = r.URL.Path
+			_ = Write(w, d)
+		}
+	})
+}
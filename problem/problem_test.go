@@ -0,0 +1,237 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"go-error-handling/utils"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestFrom_ValidationError(t *testing.T) {
+	err := custom.ValidationErrors{
+		{Field: "Age", Message: "Age cannot be less than 0", Code: 2001, Value: -1},
+		{Field: "Email", Message: "Email cannot be empty", Code: 2003, Value: ""},
+	}
+
+	d := From(err)
+
+	if d.Status != http.StatusUnprocessableEntity {
+		t.Errorf("Status = %d; want 422", d.Status)
+	}
+	if d.Type != BaseURI+"validation-error" {
+		t.Errorf("Type = %s; want %svalidation-error", d.Type, BaseURI)
+	}
+	if d.Detail != "2 fields failed validation" {
+		t.Errorf("Detail = %q; want %q", d.Detail, "2 fields failed validation")
+	}
+	if ves, ok := d.Extensions["errors"].([]*custom.ValidationError); !ok || len(ves) != 2 {
+		t.Errorf("errors extension = %v; want both validation errors", d.Extensions["errors"])
+	}
+}
+
+func TestFrom_DatabaseError(t *testing.T) {
+	tests := []struct {
+		name           string
+		retryable      bool
+		expectedStatus int
+	}{
+		{"retryable", true, http.StatusServiceUnavailable},
+		{"permanent", false, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := fmt.Errorf("list users: %w", &database.DatabaseError{
+				Operation: "SELECT",
+				Table:     "users",
+				Query:     "SELECT * FROM users WHERE email = 'secret@example.com'",
+				Err:       errors.New("connection timeout"),
+				Timestamp: time.Now(),
+				Retryable: tt.retryable,
+			})
+
+			d := From(err)
+
+			if d.Status != tt.expectedStatus {
+				t.Errorf("Status = %d; want %d", d.Status, tt.expectedStatus)
+			}
+			if d.Extensions["table"] != "users" || d.Extensions["retryable"] != tt.retryable {
+				t.Errorf("Extensions = %v; want table users and retryable %v", d.Extensions, tt.retryable)
+			}
+
+			data, _ := json.Marshal(d)
+			if strings.Contains(string(data), "secret@example.com") {
+				t.Errorf("problem document should not leak the query: %s", data)
+			}
+		})
+	}
+}
+
+func TestFrom_Sentinels(t *testing.T) {
+	tests := []struct {
+		err            error
+		expectedStatus int
+	}{
+		{utils.ErrUserNotFound, http.StatusNotFound},
+		{utils.ErrUnauthorized, http.StatusUnauthorized},
+		{utils.ErrDuplicateEmail, http.StatusConflict},
+		{utils.ErrDatabaseTimeout, http.StatusGatewayTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			d := From(fmt.Errorf("handler: %w", tt.err))
+			if d.Status != tt.expectedStatus {
+				t.Errorf("Status = %d; want %d", d.Status, tt.expectedStatus)
+			}
+			if d.Detail != tt.err.Error() {
+				t.Errorf("Detail = %q; want %q", d.Detail, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestFrom_RegisteredHTTPStatus(t *testing.T) {
+	// Register a wrapper rather than the sentinel itself so other tests
+	// still see the built-in 404.
+	errArchived := fmt.Errorf("user archived: %w", utils.ErrUserNotFound)
+	t.Cleanup(utils.RegisterHTTPStatus(errArchived, http.StatusGone))
+
+	d := From(fmt.Errorf("handler: %w", errArchived))
+	if d.Status != http.StatusGone || d.Title != http.StatusText(http.StatusGone) {
+		t.Errorf("From() = %+v; want the registered 410", d)
+	}
+	if d.Type != BaseURI+"user-not-found" {
+		t.Errorf("Type = %q; want the sentinel's problem type", d.Type)
+	}
+	if got := utils.HTTPStatus(errArchived); got != d.Status {
+		t.Errorf("HTTPStatus() = %d but From().Status = %d; want them to agree", got, d.Status)
+	}
+}
+
+func TestFrom_PermissionError(t *testing.T) {
+	d := From(fmt.Errorf("cancel order: %w", &utils.PermissionError{Subject: "user:7", Action: "cancel", Resource: "order:42"}))
+
+	if d.Status != http.StatusForbidden || d.Type != BaseURI+"forbidden" {
+		t.Errorf("From(PermissionError) = %+v; want a 403 forbidden problem", d)
+	}
+	if d.Detail != "user:7 may not cancel order:42" {
+		t.Errorf("Detail = %q; want who, what and where", d.Detail)
+	}
+	if d.Extensions["subject"] != "user:7" || d.Extensions["action"] != "cancel" || d.Extensions["resource"] != "order:42" {
+		t.Errorf("Extensions = %v; want subject, action and resource", d.Extensions)
+	}
+}
+
+func TestFrom_UnknownAndNil(t *testing.T) {
+	d := From(errors.New("pq: password authentication failed for user admin"))
+	if d.Status != http.StatusInternalServerError || d.Type != "about:blank" {
+		t.Errorf("From(unknown) = %+v; want generic 500", d)
+	}
+	if d.Detail != "" {
+		t.Errorf("From(unknown) should not expose internal detail, got %q", d.Detail)
+	}
+
+	if From(nil) != nil {
+		t.Error("From(nil) should return nil")
+	}
+}
+
+func TestDetails_MarshalJSON(t *testing.T) {
+	d := &Details{
+		Type:       BaseURI + "user-not-found",
+		Title:      "Not Found",
+		Status:     404,
+		Instance:   "/users/42",
+		Extensions: map[string]any{"userId": 42, "status": "ignored"},
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		t.Fatalf("json.Marshal returned unexpected error: %v", err)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("json.Unmarshal returned unexpected error: %v", err)
+	}
+	if doc["status"] != float64(404) {
+		t.Errorf("standard members must win over extensions, got status %v", doc["status"])
+	}
+	if doc["userId"] != float64(42) || doc["instance"] != "/users/42" {
+		t.Errorf("document = %v; want userId and instance members", doc)
+	}
+	if _, ok := doc["detail"]; ok {
+		t.Error("empty detail should be omitted")
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("load user: %w", utils.ErrUserNotFound)
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/users/42", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("status = %d; want 404", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Content-Type = %q; want %q", ct, ContentType)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if doc["instance"] != "/users/42" {
+		t.Errorf("instance = %v; want /users/42", doc["instance"])
+	}
+}
+
+func TestHandler_Success(t *testing.T) {
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		w.WriteHeader(http.StatusNoContent)
+		return nil
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if rec.Code != http.StatusNoContent {
+		t.Errorf("status = %d; want 204", rec.Code)
+	}
+}
+
+func TestHandler_RateLimited(t *testing.T) {
+	resetAt := time.Now().Add(90 * time.Second)
+	handler := Handler(func(w http.ResponseWriter, r *http.Request) error {
+		return fmt.Errorf("search: %w", &utils.RateLimitError{Limit: 100, ResetAt: resetAt})
+	})
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/search", nil))
+
+	if rec.Code != http.StatusTooManyRequests {
+		t.Errorf("status = %d; want 429", rec.Code)
+	}
+	if retryAfter := rec.Header().Get("Retry-After"); retryAfter != "90" {
+		t.Errorf("Retry-After = %q; want 90", retryAfter)
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &doc); err != nil {
+		t.Fatalf("response is not JSON: %v", err)
+	}
+	if doc["type"] != BaseURI+"rate-limited" || doc["limit"] != float64(100) || doc["remaining"] != float64(0) {
+		t.Errorf("document = %v; want the rate-limited type with limit and remaining", doc)
+	}
+}
@@ -1,48 +1,84 @@
 package user
 
 import (
-	"errors"
+	"context"
 	"fmt"
 	"go-error-handling/custom"
 	"go-error-handling/database"
 	"go-error-handling/utils"
-	"time"
 )
 
 type ValidationError = custom.ValidationError
 
+// codes is the user domain, 2000-2999.
+var codes = custom.MustDomain("user", 2000)
+
+func init() {
+	codes.MustRegister(custom.CodeNegative, "age is negative")
+	codes.MustRegister(custom.CodeTooLarge, "age exceeds the maximum")
+	codes.MustRegister(custom.CodeRequired, "email is empty")
+	codes.MustRegister(custom.CodeInvalidFormat, "email format is invalid")
+	custom.MustRegisterMessageTemplate(codes.Code(custom.CodeNegative), "{{.Field}} cannot be negative")
+}
+
 type User struct {
 	ID    int
 	Email string
 	Age   int
 }
 
+// ValidateUser reports every invalid field at once as a
+// custom.ValidationErrors, so forms can highlight all problems together.
 func ValidateUser(user User) error {
-	if user.Age < 0 {
-		return &ValidationError{
-			Field:   "Age",
-			Message: "Age cannot be negative",
-			Code:    2001,
-			Value:   user.Age,
-		}
+	if errs := collectValidationErrors(user); len(errs) > 0 {
+		return custom.ValidationErrors(errs)
 	}
-	if user.Age > 130 {
-		return &ValidationError{
-			Field:   "Age",
-			Message: "Age cannot be greater than 130",
-			Code:    2002,
-			Value:   user.Age,
+	return nil
+}
+
+// ValidateUserList validates every user in a bulk submission and reports the
+// failures keyed by slice index. Valid users are left out of the map.
+func ValidateUserList(users []User) map[int][]*custom.ValidationError {
+	report := make(map[int][]*custom.ValidationError)
+	for i, user := range users {
+		if errs := collectValidationErrors(user); len(errs) > 0 {
+			report[i] = errs
 		}
 	}
+	return report
+}
+
+func collectValidationErrors(user User) []*ValidationError {
+	var errs []*ValidationError
+	// The domain codes (2001, 2002) predate the shared rule codes and
+	// clients already depend on them.
+	if err := custom.ValidateBounds("Age", user.Age, 0, 130, codes.Code(custom.CodeNegative), codes.Code(custom.CodeTooLarge)); err != nil {
+		errs = append(errs, err)
+	}
 	if user.Email == "" {
-		return &ValidationError{
+		errs = append(errs, &ValidationError{
 			Field:   "Email",
 			Message: "Email cannot be empty",
-			Code:    2003,
+			Code:    codes.Code(custom.CodeRequired),
 			Value:   user.Email,
-		}
+		})
+	}
+	return errs
+}
+
+// ParseEmail validates email syntax and splits it on the last "@" so callers
+// can route on the domain. Failures are reported as a ValidationError with
+// code 2013 (custom.CodeInvalidFormat).
+func ParseEmail(email string) (local, domain string, err error) {
+	if local, domain, ok := custom.SplitEmail(email); ok {
+		return local, domain, nil
+	}
+	return "", "", &ValidationError{
+		Field:   "Email",
+		Message: "Email format is invalid",
+		Code:    codes.Code(custom.CodeInvalidFormat),
+		Value:   email,
 	}
-	return nil
 }
 
 func FindUserByEmail(email string) (*User, error) {
@@ -52,14 +88,40 @@ func FindUserByEmail(email string) (*User, error) {
 	return nil, utils.ErrUserNotFound
 }
 
+// Store backs QueryUsers and ListUsers. The default simulates a users table
+// that always times out; examples and tests swap in a database.Fake with
+// data and their own fault settings.
+var Store = database.NewFake().FailTable("users", database.KindTimeout)
+
 func QueryUsers(limit int) error {
-	// Simulate database error
-	return &database.DatabaseError{
-		Operation: "SELECT",
-		Table:     "users",
-		Query:     fmt.Sprintf("SELECT * FROM users LIMIT %d", limit),
-		Err:       errors.New("connection timeout"),
-		Timestamp: time.Now(),
-		Retryable: true,
+	_, err := Store.Query(context.Background(), "users", limit)
+	return err
+}
+
+// ListUsers returns up to limit users from Store. Rows that are not User
+// values are skipped.
+func ListUsers(ctx context.Context, limit int) ([]User, error) {
+	rows, err := Store.Query(ctx, "users", limit)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+	users := make([]User, 0, len(rows))
+	for _, row := range rows {
+		if u, ok := row.(User); ok {
+			users = append(users, u)
+		}
 	}
+	return users, nil
+}
+
+// ValidateUserAsync runs ValidateUser in a goroutine and delivers exactly one
+// result before closing the channel. The channel is buffered so the goroutine
+// never leaks if the caller stops listening.
+func ValidateUserAsync(u User) <-chan error {
+	result := make(chan error, 1)
+	go func() {
+		defer close(result)
+		result <- ValidateUser(u)
+	}()
+	return result
 }
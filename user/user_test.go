@@ -1,13 +1,16 @@
 package user
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"go-error-handling/custom"
 	"go-error-handling/database"
+	"go-error-handling/internal/errtest"
 	"go-error-handling/utils"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestValidateUser_Success(t *testing.T) {
@@ -208,3 +211,252 @@ func TestQueryUsers_ReturnsError(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateUserList(t *testing.T) {
+	users := []User{
+		{ID: 1, Email: "valid@example.com", Age: 30},
+		{ID: 2, Email: "", Age: -1},
+		{ID: 3, Email: "also-valid@example.com", Age: 130},
+		{ID: 4, Email: "old@example.com", Age: 200},
+	}
+
+	report := ValidateUserList(users)
+
+	if len(report) != 2 {
+		t.Fatalf("ValidateUserList returned %d entries; want 2: %v", len(report), report)
+	}
+	for _, validIndex := range []int{0, 2} {
+		if _, ok := report[validIndex]; ok {
+			t.Errorf("ValidateUserList should omit valid index %d", validIndex)
+		}
+	}
+
+	expected := map[int][]int{
+		1: {2001, 2003},
+		3: {2002},
+	}
+	for index, codes := range expected {
+		errs, ok := report[index]
+		if !ok {
+			t.Errorf("ValidateUserList missing invalid index %d", index)
+			continue
+		}
+		if len(errs) != len(codes) {
+			t.Errorf("index %d has %d errors; want %d", index, len(errs), len(codes))
+			continue
+		}
+		for i, code := range codes {
+			if errs[i].Code != code {
+				t.Errorf("index %d error %d code = %d; want %d", index, i, errs[i].Code, code)
+			}
+		}
+	}
+}
+
+func TestValidateUserList_Empty(t *testing.T) {
+	report := ValidateUserList(nil)
+	if len(report) != 0 {
+		t.Errorf("ValidateUserList(nil) = %v; want empty report", report)
+	}
+}
+
+func TestValidateUserAsync(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        User
+		expectError bool
+	}{
+		{"valid user", User{ID: 1, Email: "test@example.com", Age: 25}, false},
+		{"invalid user", User{ID: 2, Email: "", Age: 25}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := ValidateUserAsync(tt.user)
+
+			err, ok := <-results
+			if !ok {
+				t.Fatal("ValidateUserAsync channel closed before delivering a result")
+			}
+			if tt.expectError {
+				var validationErr *custom.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Errorf("Expected ValidationError, got %T", err)
+				}
+			} else if err != nil {
+				t.Errorf("ValidateUserAsync(%+v) returned unexpected error: %v", tt.user, err)
+			}
+
+			// The channel must close after the single result
+			if _, ok := <-results; ok {
+				t.Error("ValidateUserAsync channel should be closed after one result")
+			}
+		})
+	}
+}
+
+func TestParseEmail_Valid(t *testing.T) {
+	tests := []struct {
+		email  string
+		local  string
+		domain string
+	}{
+		{"test@example.com", "test", "example.com"},
+		{"a@b.c", "a", "b.c"},
+		{"ops@mail.eu.example.com", "ops", "mail.eu.example.com"},
+		{`"odd@local"@example.com`, `"odd@local"`, "example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			local, domain, err := ParseEmail(tt.email)
+			if err != nil {
+				t.Fatalf("ParseEmail(%q) returned unexpected error: %v", tt.email, err)
+			}
+			if local != tt.local || domain != tt.domain {
+				t.Errorf("ParseEmail(%q) = (%q, %q); want (%q, %q)", tt.email, local, domain, tt.local, tt.domain)
+			}
+		})
+	}
+}
+
+func TestParseEmail_Invalid(t *testing.T) {
+	invalidEmails := []string{"", "no-at-sign", "@example.com", "user@", "user@localhost", "user@example.", "with space@example.com"}
+
+	for _, email := range invalidEmails {
+		t.Run(fmt.Sprintf("email_%q", email), func(t *testing.T) {
+			local, domain, err := ParseEmail(email)
+			if err == nil {
+				t.Fatalf("ParseEmail(%q) expected error but got none", email)
+			}
+			if local != "" || domain != "" {
+				t.Errorf("ParseEmail(%q) should return empty parts on error, got (%q, %q)", email, local, domain)
+			}
+
+			var validationErr *custom.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("Expected ValidationError, got %T", err)
+			}
+			if validationErr.Field != "Email" {
+				t.Errorf("Expected field 'Email', got '%s'", validationErr.Field)
+			}
+			if validationErr.Code != 2013 {
+				t.Errorf("Expected code 2013, got %d", validationErr.Code)
+			}
+			if validationErr.Value != email {
+				t.Errorf("Expected value %q, got %v", email, validationErr.Value)
+			}
+		})
+	}
+}
+
+func TestValidateUser_NoAllocOnSuccess(t *testing.T) {
+	user := User{ID: 1, Email: "test@example.com", Age: 25}
+	errtest.AssertNoAllocOnSuccess(t, func() error {
+		return ValidateUser(user)
+	})
+}
+
+func TestValidateUser_ReportsAllFailures(t *testing.T) {
+	user := User{ID: 1, Email: "", Age: -5}
+
+	err := ValidateUser(user)
+
+	var validationErrs custom.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("Expected ValidationErrors, got %T", err)
+	}
+	if len(validationErrs) != 2 {
+		t.Fatalf("Expected 2 validation errors, got %d: %v", len(validationErrs), err)
+	}
+	if validationErrs[0].Code != 2001 || validationErrs[1].Code != 2003 {
+		t.Errorf("Expected codes [2001 2003], got [%d %d]", validationErrs[0].Code, validationErrs[1].Code)
+	}
+}
+
+func TestUserCodesRegistered(t *testing.T) {
+	for _, code := range []int{2001, 2002, 2003, 2013} {
+		if owner, ok := custom.DefaultCodeRegistry.Owner(code); !ok || owner != "user" {
+			t.Errorf("code %d owner = (%q, %v); want (\"user\", true)", code, owner, ok)
+		}
+		if _, ok := custom.Describe(code); !ok {
+			t.Errorf("code %d should have a registered description", code)
+		}
+	}
+}
+
+func TestValidateUser_AgeConstraints(t *testing.T) {
+	err := ValidateUser(User{ID: 1, Email: "test@example.com", Age: 131})
+
+	var validationErr *custom.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError, got %T", err)
+	}
+	if max, ok := validationErr.Constraints["max"]; !ok || max != 130 {
+		t.Errorf("Expected max constraint 130, got %v", validationErr.Constraints)
+	}
+}
+
+func TestUserCodes_Canonical(t *testing.T) {
+	expected := map[int]custom.CanonicalCode{
+		2001: custom.CodeNegative,
+		2002: custom.CodeTooLarge,
+		2003: custom.CodeRequired,
+		2013: custom.CodeInvalidFormat,
+	}
+	for code, canonical := range expected {
+		if got, ok := custom.Canonical(code); !ok || got != canonical {
+			t.Errorf("Canonical(%d) = %v, %v; want %v", code, got, ok, canonical)
+		}
+	}
+}
+
+func TestListUsers_FromFake(t *testing.T) {
+	original := Store
+	defer func() { Store = original }()
+
+	Store = database.NewFake().Insert("users",
+		User{ID: 1, Email: "ana@example.com", Age: 30},
+		User{ID: 2, Email: "li@example.com", Age: 41},
+		"not a user",
+	)
+
+	users, err := ListUsers(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if len(users) != 2 || users[0].ID != 1 || users[1].ID != 2 {
+		t.Errorf("ListUsers() = %v; want the two stored users", users)
+	}
+	if err := QueryUsers(10); err != nil {
+		t.Errorf("QueryUsers() = %v; want success against a healthy store", err)
+	}
+}
+
+func TestListUsers_InjectedFault(t *testing.T) {
+	original := Store
+	defer func() { Store = original }()
+
+	Store = database.NewFake().FailTable("users", database.KindConnection)
+
+	_, err := ListUsers(context.Background(), 5)
+	if !database.IsConnection(err) || !database.IsRetryable(err) {
+		t.Errorf("ListUsers() = %v; want a retryable connection error", err)
+	}
+}
+
+func TestQueryUsers_DeterministicTimestamp(t *testing.T) {
+	frozen := time.Date(2024, 6, 1, 9, 0, 0, 0, time.UTC)
+	defer database.SetClock(database.FrozenClock{At: frozen})()
+
+	var dbErr *database.DatabaseError
+	if err := QueryUsers(5); !errors.As(err, &dbErr) {
+		t.Fatalf("QueryUsers() = %v; want DatabaseError", err)
+	}
+	if !dbErr.Timestamp.Equal(frozen) {
+		t.Errorf("Timestamp = %v; want %v", dbErr.Timestamp, frozen)
+	}
+	if !strings.Contains(dbErr.Error(), "timestamp: 2024-06-01T09:00:00Z") {
+		t.Errorf("Error() = %q; want the frozen timestamp", dbErr.Error())
+	}
+}
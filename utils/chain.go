@@ -0,0 +1,14 @@
+package utils
+
+import "fmt"
+
+// BuildSampleErrorChain wraps ErrDatabaseTimeout depth times so benchmarks
+// can measure errors.Is/Unwrap traversal against a known, stable chain.
+// A depth of zero (or less) returns the bare sentinel.
+func BuildSampleErrorChain(depth int) error {
+	var err error = ErrDatabaseTimeout
+	for i := 1; i <= depth; i++ {
+		err = fmt.Errorf("layer %d: %w", i, err)
+	}
+	return err
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/wrapping"
+	"testing"
+)
+
+func TestBuildSampleErrorChain_Depth(t *testing.T) {
+	depths := []int{0, 1, 10, 100}
+
+	for _, depth := range depths {
+		t.Run(fmt.Sprintf("depth_%d", depth), func(t *testing.T) {
+			err := BuildSampleErrorChain(depth)
+
+			// Count the Unwrap steps needed to reach the sentinel
+			got, found := 0, false
+			for current := range wrapping.All(err) {
+				if current == ErrDatabaseTimeout {
+					found = true
+					break
+				}
+				got++
+			}
+			if !found {
+				t.Fatalf("BuildSampleErrorChain(%d) chain does not end in ErrDatabaseTimeout", depth)
+			}
+
+			if got != depth {
+				t.Errorf("BuildSampleErrorChain(%d) depth = %d; want %d", depth, got, depth)
+			}
+			if !errors.Is(err, ErrDatabaseTimeout) {
+				t.Errorf("BuildSampleErrorChain(%d) should match ErrDatabaseTimeout", depth)
+			}
+		})
+	}
+}
+
+func TestBuildSampleErrorChain_Deterministic(t *testing.T) {
+	first := BuildSampleErrorChain(5)
+	second := BuildSampleErrorChain(5)
+
+	if first.Error() != second.Error() {
+		t.Errorf("BuildSampleErrorChain(5) should be deterministic: %q vs %q", first, second)
+	}
+}
+
+func BenchmarkIsDeep(b *testing.B) {
+	for _, depth := range []int{1, 10, 100} {
+		err := BuildSampleErrorChain(depth)
+		b.Run(fmt.Sprintf("depth_%d", depth), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if !errors.Is(err, ErrDatabaseTimeout) {
+					b.Fatal("sentinel not found in chain")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code identifies a package sentinel so callers can switch on it instead of
+// chaining errors.Is checks. The zero value, CodeUnknown, means the error
+// carries no code.
+type Code int
+
+const (
+	CodeUnknown Code = iota
+	CodeUserNotFound
+	CodeDuplicateEmail
+	CodeInvalidPassword
+	CodeUnauthorized
+	CodeDatabaseTimeout
+	CodeValidation
+)
+
+func (c Code) String() string {
+	switch c {
+	case CodeUserNotFound:
+		return "USER_NOT_FOUND"
+	case CodeDuplicateEmail:
+		return "DUPLICATE_EMAIL"
+	case CodeInvalidPassword:
+		return "INVALID_PASSWORD"
+	case CodeUnauthorized:
+		return "UNAUTHORIZED"
+	case CodeDatabaseTimeout:
+		return "DATABASE_TIMEOUT"
+	case CodeValidation:
+		return "VALIDATION"
+	}
+	return fmt.Sprintf("Code(%d)", int(c))
+}
+
+// CodeOf returns the Code of the first error in err's chain that reports
+// one, searching joined errors in the same order as errors.Is, or
+// CodeUnknown when there is none.
+func CodeOf(err error) Code {
+	var coded interface{ Code() Code }
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+	return CodeUnknown
+}
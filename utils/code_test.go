@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestCodeOf(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Code
+	}{
+		{"ErrUserNotFound", ErrUserNotFound, CodeUserNotFound},
+		{"ErrDuplicateEmail", ErrDuplicateEmail, CodeDuplicateEmail},
+		{"ErrInvalidPassword", ErrInvalidPassword, CodeInvalidPassword},
+		{"ErrUnauthorized", ErrUnauthorized, CodeUnauthorized},
+		{"ErrDatabaseTimeout", ErrDatabaseTimeout, CodeDatabaseTimeout},
+		{"ErrValidation", ErrValidation, CodeValidation},
+		{"wrapped", fmt.Errorf("login: %w", fmt.Errorf("lookup: %w", ErrUnauthorized)), CodeUnauthorized},
+		{"joined", errors.Join(errors.New("audit failed"), ErrDuplicateEmail), CodeDuplicateEmail},
+		{"uncoded", errors.New("boom"), CodeUnknown},
+		{"nil", nil, CodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CodeOf(tt.err); got != tt.expected {
+				t.Errorf("CodeOf(%v) = %v; want %v", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCode_String(t *testing.T) {
+	if CodeUserNotFound.String() != "USER_NOT_FOUND" || Code(99).String() != "Code(99)" {
+		t.Errorf("String() = %q, %q; want USER_NOT_FOUND, Code(99)", CodeUserNotFound, Code(99))
+	}
+}
@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+)
+
+// Combine2 reports an operation that failed validation and also hit the
+// database. The result matches ErrValidation and lets errors.As extract
+// both the *ValidationError and the *DatabaseError. Nil inputs are skipped.
+func Combine2(ve *custom.ValidationError, de *database.DatabaseError) error {
+	var errs []error
+	if ve != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrValidation, ve))
+	}
+	if de != nil {
+		errs = append(errs, de)
+	}
+	return errors.Join(errs...)
+}
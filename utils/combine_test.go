@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"errors"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"testing"
+	"time"
+)
+
+func TestCombine2(t *testing.T) {
+	ve := &custom.ValidationError{Field: "Email", Message: "Email cannot be empty", Code: 2003, Value: ""}
+	de := &database.DatabaseError{Operation: "INSERT", Table: "users", Err: ErrDatabaseTimeout, Timestamp: time.Now(), Retryable: true}
+
+	result := Combine2(ve, de)
+
+	var validationErr *custom.ValidationError
+	if !errors.As(result, &validationErr) || validationErr != ve {
+		t.Error("Combine2 result should expose the ValidationError via errors.As")
+	}
+
+	var dbErr *database.DatabaseError
+	if !errors.As(result, &dbErr) || dbErr != de {
+		t.Error("Combine2 result should expose the DatabaseError via errors.As")
+	}
+
+	if !errors.Is(result, ErrValidation) {
+		t.Error("Combine2 result should match ErrValidation")
+	}
+	if !database.IsDatabaseError(result) {
+		t.Error("database.IsDatabaseError should hold for Combine2 result")
+	}
+	if !errors.Is(result, ErrDatabaseTimeout) {
+		t.Error("Combine2 result should keep the database cause reachable")
+	}
+}
+
+func TestCombine2_NilParts(t *testing.T) {
+	if result := Combine2(nil, nil); result != nil {
+		t.Errorf("Combine2(nil, nil) = %v; want nil", result)
+	}
+
+	ve := &custom.ValidationError{Field: "Age", Code: 2001, Value: -1}
+	result := Combine2(ve, nil)
+	if !errors.Is(result, ErrValidation) {
+		t.Error("Combine2(ve, nil) should match ErrValidation")
+	}
+	if database.IsDatabaseError(result) {
+		t.Error("Combine2(ve, nil) should not report a DatabaseError")
+	}
+}
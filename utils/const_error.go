@@ -0,0 +1,29 @@
+package utils
+
+// ConstError is an error that can be declared as a constant. Two
+// ConstErrors are equal, and so match with errors.Is, exactly when their
+// messages are equal, so a sentinel keeps its identity without a package
+// variable that could be reassigned.
+type ConstError string
+
+func (e ConstError) Error() string {
+	return string(e)
+}
+
+// constErrorCodes gives the package sentinels their Code.
+var constErrorCodes = map[ConstError]Code{
+	ErrUserNotFound:    CodeUserNotFound,
+	ErrDuplicateEmail:  CodeDuplicateEmail,
+	ErrInvalidPassword: CodeInvalidPassword,
+	ErrUnauthorized:    CodeUnauthorized,
+	ErrDatabaseTimeout: CodeDatabaseTimeout,
+	ErrValidation:      CodeValidation,
+}
+
+// Code returns the sentinel's Code, or CodeUnknown if its message is not
+// one of the package sentinels'. Identity is by message, so a ConstError
+// declared anywhere as "user not found" is ErrUserNotFound and reports
+// CodeUserNotFound.
+func (e ConstError) Code() Code {
+	return constErrorCodes[e]
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestConstError_Identity(t *testing.T) {
+	wrapped := fmt.Errorf("find: %w", ErrUserNotFound)
+
+	if !errors.Is(wrapped, ErrUserNotFound) {
+		t.Error("wrapped ErrUserNotFound should match itself")
+	}
+	if !errors.Is(wrapped, ConstError("user not found")) {
+		t.Error("ConstErrors with the same message should match")
+	}
+	if errors.Is(wrapped, errors.New("user not found")) {
+		t.Error("an errors.New value with the same message should not match")
+	}
+}
+
+func TestConstError_Code(t *testing.T) {
+	if ErrDuplicateEmail.Code() != CodeDuplicateEmail {
+		t.Errorf("ErrDuplicateEmail.Code() = %v; want %v", ErrDuplicateEmail.Code(), CodeDuplicateEmail)
+	}
+	if got := ConstError("quota exceeded").Code(); got != CodeUnknown {
+		t.Errorf("unknown ConstError Code() = %v; want %v", got, CodeUnknown)
+	}
+	// Identity is by message, wherever the ConstError is declared.
+	const errNotFound = ConstError("user not found")
+	if got := errNotFound.Code(); got != CodeUserNotFound {
+		t.Errorf("ConstError(%q).Code() = %v; want %v", errNotFound, got, CodeUserNotFound)
+	}
+	if got := errNotFound.UserFacing(); got != ErrUserNotFound.UserFacing() {
+		t.Errorf("ConstError(%q).UserFacing() = %q; want ErrUserNotFound's", errNotFound, got)
+	}
+}
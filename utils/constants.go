@@ -1,11 +1,23 @@
 package utils
 
-import "errors"
-
-var (
-	ErrUserNotFound    = errors.New("user not found")
-	ErrDuplicateEmail  = errors.New("email already exists")
-	ErrInvalidPassword = errors.New("invalid password")
-	ErrUnauthorized    = errors.New("unauthorized access")
-	ErrDatabaseTimeout = errors.New("database operation timed out")
+// The sentinels are constants so no package can reassign them. Each
+// carries its Code; see CodeOf.
+const (
+	ErrUserNotFound    ConstError = "user not found"
+	ErrDuplicateEmail  ConstError = "email already exists"
+	ErrInvalidPassword ConstError = "invalid password"
+	ErrUnauthorized    ConstError = "unauthorized access"
+	ErrDatabaseTimeout ConstError = "database operation timed out"
+	ErrValidation      ConstError = "validation failed"
 )
+
+// sentinels lists every package sentinel so style checks can cover them all.
+// New sentinels must be added here as well.
+var sentinels = []error{
+	ErrUserNotFound,
+	ErrDuplicateEmail,
+	ErrInvalidPassword,
+	ErrUnauthorized,
+	ErrDatabaseTimeout,
+	ErrValidation,
+}
@@ -17,6 +17,7 @@ func TestSentinelErrors_Identity(t *testing.T) {
 		{"ErrInvalidPassword", ErrInvalidPassword},
 		{"ErrUnauthorized", ErrUnauthorized},
 		{"ErrDatabaseTimeout", ErrDatabaseTimeout},
+		{"ErrValidation", ErrValidation},
 	}
 
 	for _, tt := range tests {
@@ -46,6 +47,7 @@ func TestSentinelErrors_Messages(t *testing.T) {
 		{"ErrInvalidPassword", ErrInvalidPassword, "invalid password"},
 		{"ErrUnauthorized", ErrUnauthorized, "unauthorized access"},
 		{"ErrDatabaseTimeout", ErrDatabaseTimeout, "database operation timed out"},
+		{"ErrValidation", ErrValidation, "validation failed"},
 	}
 
 	for _, tt := range tests {
@@ -65,6 +67,7 @@ func TestSentinelErrors_Uniqueness(t *testing.T) {
 		ErrInvalidPassword,
 		ErrUnauthorized,
 		ErrDatabaseTimeout,
+		ErrValidation,
 	}
 
 	for i, err1 := range sentinelErrors {
@@ -0,0 +1,27 @@
+package utils
+
+import "fmt"
+
+// CountByType tallies errors by concrete Go type name (e.g.
+// "*custom.ValidationError"). Joined errors are not counted themselves;
+// their branches are counted instead so aggregates don't hide what failed.
+func CountByType(errs []error) map[string]int {
+	counts := make(map[string]int)
+	for _, err := range errs {
+		countLeaves(err, counts)
+	}
+	return counts
+}
+
+func countLeaves(err error, counts map[string]int) {
+	if err == nil {
+		return
+	}
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, child := range joined.Unwrap() {
+			countLeaves(child, counts)
+		}
+		return
+	}
+	counts[fmt.Sprintf("%T", err)]++
+}
@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"errors"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCountByType(t *testing.T) {
+	errs := []error{
+		&custom.ValidationError{Field: "Age", Code: 2001, Value: -1},
+		&custom.ValidationError{Field: "Email", Code: 2003, Value: ""},
+		&database.DatabaseError{Operation: "SELECT", Table: "users", Err: ErrDatabaseTimeout, Timestamp: time.Now()},
+		nil,
+	}
+
+	expected := map[string]int{
+		"*custom.ValidationError": 2,
+		"*database.DatabaseError": 1,
+	}
+
+	result := CountByType(errs)
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("CountByType() = %v; want %v", result, expected)
+	}
+}
+
+func TestCountByType_JoinedErrors(t *testing.T) {
+	joined := errors.Join(
+		&custom.ValidationError{Field: "Age", Code: 2001, Value: -1},
+		errors.Join(ErrUserNotFound, &custom.ValidationError{Field: "Email", Code: 2003}),
+	)
+
+	expected := map[string]int{
+		"*custom.ValidationError": 2,
+		"utils.ConstError":        1,
+	}
+
+	result := CountByType([]error{joined})
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("CountByType(joined) = %v; want %v", result, expected)
+	}
+}
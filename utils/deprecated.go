@@ -0,0 +1,67 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+)
+
+// OnDeprecatedSentinel, when set, is called the first time a deprecated
+// sentinel is used, e.g. to log a migration warning: when errors.Is
+// matches an error carrying one against its replacement, or matches code
+// still checking for one as the errors.Is target. Comparisons that do not
+// match never call it, and each sentinel warns at most once per process.
+// Applications can set it at startup, after the declaring packages are
+// initialized.
+var OnDeprecatedSentinel func(id string, replacement error)
+
+// NewDeprecatedSentinel declares a sentinel that is kept only so existing
+// code keeps working while it migrates to replacement. It is registered
+// like NewSentinel and keeps its own message, and errors.Is treats the two
+// as one error in both directions: errors.Is(err, old) holds for errors
+// carrying replacement, and errors.Is(old, replacement) holds as well. The
+// aliasing is honored by sentinels from this package, namely ConstError and
+// NewSentinel errors. NewDeprecatedSentinel panics if replacement is nil or
+// under the same conditions as NewSentinel.
+func NewDeprecatedSentinel(namespace, name, message string, replacement error) error {
+	if replacement == nil {
+		panic(fmt.Sprintf("utils: deprecated sentinel %s.%s needs a replacement", namespace, name))
+	}
+	return registerSentinel(namespace, name, &sentinelError{msg: message, replacement: replacement})
+}
+
+// warnDeprecated reports the first use of the deprecated sentinel e once
+// a hook is installed.
+func warnDeprecated(e *sentinelError) {
+	if OnDeprecatedSentinel != nil && e.warned.CompareAndSwap(false, true) {
+		OnDeprecatedSentinel(e.id, e.replacement)
+	}
+}
+
+// Unwrap returns the replacement of a deprecated sentinel, so it matches
+// its replacement with errors.Is, and nil otherwise.
+func (e *sentinelError) Unwrap() error {
+	return e.replacement
+}
+
+func (e *sentinelError) Is(target error) bool {
+	if e.replacement != nil && errors.Is(e.replacement, target) {
+		warnDeprecated(e)
+		return true
+	}
+	return supersedes(e, target)
+}
+
+func (e ConstError) Is(target error) bool {
+	return supersedes(e, target)
+}
+
+// supersedes reports whether target is a deprecated sentinel that err
+// replaces, directly or through a chain of deprecations.
+func supersedes(err, target error) bool {
+	deprecated, ok := target.(*sentinelError)
+	if !ok || deprecated.replacement == nil || !errors.Is(err, deprecated.replacement) {
+		return false
+	}
+	warnDeprecated(deprecated)
+	return true
+}
@@ -0,0 +1,89 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestNewDeprecatedSentinel(t *testing.T) {
+	restoreSentinels(t)
+
+	errAccountMissing := NewDeprecatedSentinel("account", "missing", "account missing", ErrUserNotFound)
+
+	if !errors.Is(fmt.Errorf("find: %w", ErrUserNotFound), errAccountMissing) {
+		t.Error("errors carrying the replacement should match the deprecated sentinel")
+	}
+	if !errors.Is(fmt.Errorf("legacy: %w", errAccountMissing), ErrUserNotFound) {
+		t.Error("the deprecated sentinel should match its replacement")
+	}
+	if errors.Is(ErrDuplicateEmail, errAccountMissing) {
+		t.Error("other sentinels should not match the deprecated sentinel")
+	}
+	if errAccountMissing.Error() != "account missing" {
+		t.Errorf("Error() = %q; want the deprecated sentinel's own message", errAccountMissing.Error())
+	}
+	if found, ok := Lookup("account.missing"); !ok || found != errAccountMissing {
+		t.Errorf("Lookup(account.missing) = %v, %v; want the deprecated sentinel", found, ok)
+	}
+}
+
+func TestNewDeprecatedSentinel_Chained(t *testing.T) {
+	restoreSentinels(t)
+
+	errLoginFailed := NewSentinel("auth", "login_failed", "login failed")
+	errBadPassword := NewDeprecatedSentinel("auth", "bad_password", "bad password", errLoginFailed)
+	errWrongPassword := NewDeprecatedSentinel("auth", "wrong_password", "wrong password", errBadPassword)
+
+	if !errors.Is(errLoginFailed, errWrongPassword) {
+		t.Error("the final replacement should match every deprecated sentinel before it")
+	}
+	if !errors.Is(errWrongPassword, errLoginFailed) {
+		t.Error("the oldest sentinel should match the final replacement")
+	}
+}
+
+func TestNewDeprecatedSentinel_Hook(t *testing.T) {
+	restoreSentinels(t)
+	defer func() { OnDeprecatedSentinel = nil }()
+
+	// Declared first, as the declaring package's init would be, before
+	// the consuming application installs its hook.
+	errForbidden := NewDeprecatedSentinel("auth", "forbidden", "forbidden", ErrUnauthorized)
+
+	var warned []string
+	OnDeprecatedSentinel = func(id string, replacement error) {
+		warned = append(warned, fmt.Sprintf("%s -> %v", id, replacement))
+	}
+
+	if errors.Is(fmt.Errorf("check: %w", ErrUserNotFound), errForbidden) {
+		t.Fatal("unrelated errors should not match the deprecated sentinel")
+	}
+	if len(warned) != 0 {
+		t.Errorf("hook calls = %v; want none when the deprecated sentinel is not matched", warned)
+	}
+
+	// Comparing against the deprecated sentinel.
+	errors.Is(fmt.Errorf("handler: %w", ErrUnauthorized), errForbidden)
+	// Returning the deprecated sentinel to code that checks the new one.
+	errors.Is(fmt.Errorf("legacy: %w", errForbidden), ErrUnauthorized)
+
+	// Registry helpers compare against every sentinel without warning.
+	Meta(fmt.Errorf("handler: %w", ErrDuplicateEmail))
+
+	expected := []string{"auth.forbidden -> unauthorized access"}
+	if !slices.Equal(warned, expected) {
+		t.Errorf("hook calls = %v; want %v, once however often it is used", warned, expected)
+	}
+}
+
+func TestNewDeprecatedSentinel_NilReplacementPanics(t *testing.T) {
+	restoreSentinels(t)
+	defer func() {
+		if recover() == nil {
+			t.Error("NewDeprecatedSentinel with a nil replacement should panic")
+		}
+	}()
+	NewDeprecatedSentinel("auth", "gone", "gone", nil)
+}
@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+)
+
+// Process exit codes returned by ExitCode.
+const (
+	ExitOK         = 0
+	ExitInternal   = 1
+	ExitValidation = 2
+	ExitNotFound   = 3
+	ExitTimeout    = 4
+)
+
+// ExitCode maps err to a process exit code so scripts can branch on the
+// class of failure: ExitValidation for validation errors, ExitNotFound for
+// missing users or rows, ExitTimeout for timeouts and deadlines, and
+// ExitInternal for anything else. It returns ExitOK for nil.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, ErrValidation) || len(custom.AllValidationErrors(err)) > 0:
+		return ExitValidation
+	case errors.Is(err, ErrUserNotFound) || database.IsNotFound(err):
+		return ExitNotFound
+	case errors.Is(err, ErrDatabaseTimeout) || database.IsTimeout(err) || errors.Is(err, context.DeadlineExceeded):
+		return ExitTimeout
+	}
+	return ExitInternal
+}
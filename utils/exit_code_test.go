@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"testing"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"nil", nil, ExitOK},
+		{"validation error", &custom.ValidationError{Field: "Age", Code: 2001}, ExitValidation},
+		{"validation sentinel", fmt.Errorf("import: %w", ErrValidation), ExitValidation},
+		{"user not found", fmt.Errorf("find: %w", ErrUserNotFound), ExitNotFound},
+		{"database not found", &database.DatabaseError{Kind: database.KindNotFound}, ExitNotFound},
+		{"timeout sentinel", ErrDatabaseTimeout, ExitTimeout},
+		{"database timeout", &database.DatabaseError{Kind: database.KindTimeout}, ExitTimeout},
+		{"deadline", fmt.Errorf("fetch: %w", context.DeadlineExceeded), ExitTimeout},
+		{"internal", errors.New("boom"), ExitInternal},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.expected {
+				t.Errorf("ExitCode(%v) = %d; want %d", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
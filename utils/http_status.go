@@ -0,0 +1,127 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"net/http"
+	"strings"
+)
+
+type httpStatusMapping struct {
+	err    error
+	status int
+}
+
+// sentinelStatuses maps sentinels to the status HTTPStatus reports for them.
+var sentinelStatuses = []httpStatusMapping{
+	{ErrUserNotFound, http.StatusNotFound},
+	{ErrUnauthorized, http.StatusUnauthorized},
+	{ErrInvalidPassword, http.StatusUnauthorized},
+	{ErrDuplicateEmail, http.StatusConflict},
+	{ErrDatabaseTimeout, http.StatusGatewayTimeout},
+	{ErrValidation, http.StatusUnprocessableEntity},
+}
+
+// registeredStatuses holds RegisterHTTPStatus mappings, newest first.
+var registeredStatuses []httpStatusMapping
+
+// RegisterHTTPStatus makes HTTPStatus report status for any error matching
+// target with errors.Is. Registered mappings are checked before the
+// built-in ones, later registrations first, so they can override them.
+// Register during init; the registry is not safe for concurrent use. The
+// returned function restores the mappings registered before this call,
+// typically with t.Cleanup in tests.
+func RegisterHTTPStatus(target error, status int) (restore func()) {
+	previous := registeredStatuses
+	registeredStatuses = append([]httpStatusMapping{{target, status}}, registeredStatuses...)
+	return func() { registeredStatuses = previous }
+}
+
+// HTTPStatus returns the response status for err: registered mappings
+// first, then 422 for validation errors, 503 for a retryable DatabaseError
+// and 500 for any other, 403 for a PermissionError, 429 for a
+// RateLimitError, then the sentinels (404, 401, 409, ...). Unknown errors
+// are 500 and nil is 200.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	for _, m := range registeredStatuses {
+		if errors.Is(err, m.err) {
+			return m.status
+		}
+	}
+	if len(custom.AllValidationErrors(err)) > 0 {
+		return http.StatusUnprocessableEntity
+	}
+	if database.IsDatabaseError(err) {
+		if database.IsRetryable(err) {
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusInternalServerError
+	}
+	var permErr *PermissionError
+	if errors.As(err, &permErr) {
+		return http.StatusForbidden
+	}
+	if IsRateLimited(err) {
+		return http.StatusTooManyRequests
+	}
+	for _, m := range sentinelStatuses {
+		if errors.Is(err, m.err) {
+			return m.status
+		}
+	}
+	return http.StatusInternalServerError
+}
+
+// FromHTTPStatus converts a response from another service into this
+// package's errors so callers can keep using errors.Is/As across service
+// boundaries. Statuses below 400 are not errors and return nil.
+func FromHTTPStatus(status int, body string) error {
+	if status < http.StatusBadRequest {
+		return nil
+	}
+	switch status {
+	case http.StatusNotFound:
+		return fmt.Errorf("remote returned %d: %w", status, ErrUserNotFound)
+	case http.StatusUnauthorized:
+		return fmt.Errorf("remote returned %d: %w", status, ErrUnauthorized)
+	case http.StatusConflict:
+		return fmt.Errorf("remote returned %d: %w", status, ErrDuplicateEmail)
+	case http.StatusGatewayTimeout:
+		return fmt.Errorf("remote returned %d: %w", status, ErrDatabaseTimeout)
+	case http.StatusUnprocessableEntity:
+		return validationErrorFromBody(body)
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return fmt.Errorf("unexpected http status %d (%s)", status, http.StatusText(status))
+	}
+	return fmt.Errorf("unexpected http status %d (%s): %s", status, http.StatusText(status), body)
+}
+
+// validationErrorFromBody decodes the JSON validation error(s) the remote
+// sent and otherwise keeps the raw body as the message.
+func validationErrorFromBody(body string) error {
+	if ve, err := custom.FromJSON([]byte(body)); err == nil && hasField(ve) {
+		return ve
+	}
+	message := strings.TrimSpace(body)
+	if message == "" {
+		message = http.StatusText(http.StatusUnprocessableEntity)
+	}
+	return &custom.ValidationError{Message: message}
+}
+
+func hasField(err error) bool {
+	for _, ve := range custom.AllValidationErrors(err) {
+		if ve.Field != "" {
+			return true
+		}
+	}
+	return false
+}
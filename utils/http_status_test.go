@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestFromHTTPStatus_Sentinels(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected error
+	}{
+		{404, ErrUserNotFound},
+		{401, ErrUnauthorized},
+		{409, ErrDuplicateEmail},
+		{504, ErrDatabaseTimeout},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected.Error(), func(t *testing.T) {
+			err := FromHTTPStatus(tt.status, "")
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("FromHTTPStatus(%d) = %v; want %v", tt.status, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFromHTTPStatus_ValidationJSON(t *testing.T) {
+	body := `{"Field":"Age","Message":"Age cannot be negative","Code":2001,"Value":-1}`
+
+	err := FromHTTPStatus(422, body)
+
+	var validationErr *custom.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("FromHTTPStatus(422) = %T; want *custom.ValidationError", err)
+	}
+	if validationErr.Field != "Age" {
+		t.Errorf("Expected field 'Age', got '%s'", validationErr.Field)
+	}
+	if validationErr.Code != 2001 {
+		t.Errorf("Expected code 2001, got %d", validationErr.Code)
+	}
+	if validationErr.Message != "Age cannot be negative" {
+		t.Errorf("Expected message 'Age cannot be negative', got '%s'", validationErr.Message)
+	}
+}
+
+func TestFromHTTPStatus_ValidationPlainBody(t *testing.T) {
+	err := FromHTTPStatus(422, "email is malformed")
+
+	var validationErr *custom.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("FromHTTPStatus(422) = %T; want *custom.ValidationError", err)
+	}
+	if validationErr.Message != "email is malformed" {
+		t.Errorf("Expected the raw body as message, got '%s'", validationErr.Message)
+	}
+}
+
+func TestFromHTTPStatus_Unmapped(t *testing.T) {
+	err := FromHTTPStatus(418, "short and stout")
+	if err == nil {
+		t.Fatal("FromHTTPStatus(418) expected error but got none")
+	}
+
+	for _, component := range []string{"418", "I'm a teapot", "short and stout"} {
+		if !strings.Contains(err.Error(), component) {
+			t.Errorf("FromHTTPStatus(418) should contain '%s', got: %s", component, err.Error())
+		}
+	}
+	for _, sentinel := range sentinels {
+		if errors.Is(err, sentinel) {
+			t.Errorf("FromHTTPStatus(418) should not match sentinel %v", sentinel)
+		}
+	}
+}
+
+func TestFromHTTPStatus_Success(t *testing.T) {
+	for _, status := range []int{200, 204, 302} {
+		if err := FromHTTPStatus(status, ""); err != nil {
+			t.Errorf("FromHTTPStatus(%d) = %v; want nil", status, err)
+		}
+	}
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"nil", nil, http.StatusOK},
+		{"user not found", fmt.Errorf("find: %w", ErrUserNotFound), http.StatusNotFound},
+		{"unauthorized", ErrUnauthorized, http.StatusUnauthorized},
+		{"permission error", fmt.Errorf("delete: %w", &PermissionError{Subject: "user:7", Action: "delete", Resource: "order:42"}), http.StatusForbidden},
+		{"duplicate email", ErrDuplicateEmail, http.StatusConflict},
+		{"validation error", &custom.ValidationError{Field: "Age", Code: 2001}, http.StatusUnprocessableEntity},
+		{"validation errors", custom.ValidationErrors{{Field: "Age"}, {Field: "Email"}}, http.StatusUnprocessableEntity},
+		{"retryable database error", &database.DatabaseError{Operation: "SELECT", Retryable: true}, http.StatusServiceUnavailable},
+		{"permanent database error", &database.DatabaseError{Operation: "INSERT", Kind: database.KindConstraintViolation}, http.StatusInternalServerError},
+		{"rate limited", fmt.Errorf("search: %w", &RateLimitError{Limit: 100}), http.StatusTooManyRequests},
+		{"unknown", errors.New("boom"), http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HTTPStatus(tt.err); got != tt.expected {
+				t.Errorf("HTTPStatus(%v) = %d; want %d", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRegisterHTTPStatus(t *testing.T) {
+	original := registeredStatuses
+	defer func() { registeredStatuses = original }()
+
+	errQuotaExceeded := errors.New("quota exceeded")
+	RegisterHTTPStatus(errQuotaExceeded, http.StatusTooManyRequests)
+	RegisterHTTPStatus(ErrUserNotFound, http.StatusGone)
+
+	if got := HTTPStatus(fmt.Errorf("upload: %w", errQuotaExceeded)); got != http.StatusTooManyRequests {
+		t.Errorf("HTTPStatus(registered) = %d; want %d", got, http.StatusTooManyRequests)
+	}
+	if got := HTTPStatus(ErrUserNotFound); got != http.StatusGone {
+		t.Errorf("HTTPStatus(overridden sentinel) = %d; want %d", got, http.StatusGone)
+	}
+
+	restore := RegisterHTTPStatus(ErrUserNotFound, http.StatusTeapot)
+	restore()
+	if got := HTTPStatus(ErrUserNotFound); got != http.StatusGone {
+		t.Errorf("HTTPStatus() after restore = %d; want the earlier %d", got, http.StatusGone)
+	}
+}
@@ -0,0 +1,11 @@
+package utils
+
+// Ignore returns nil when err matches any of the given sentinels and err
+// unchanged otherwise. It expresses "expected absence" cases such as an
+// idempotent delete that treats ErrUserNotFound as success.
+func Ignore(err error, sentinels ...error) error {
+	if IsAny(err, sentinels...) {
+		return nil
+	}
+	return err
+}
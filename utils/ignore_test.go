@@ -0,0 +1,32 @@
+package utils
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIgnore(t *testing.T) {
+	wrappedNotFound := fmt.Errorf("delete user 42: %w", ErrUserNotFound)
+	wrappedUnauthorized := fmt.Errorf("delete user 42: %w", ErrUnauthorized)
+
+	tests := []struct {
+		name      string
+		err       error
+		sentinels []error
+		expected  error
+	}{
+		{"listed sentinel is ignored", wrappedNotFound, []error{ErrUserNotFound}, nil},
+		{"any listed sentinel is ignored", wrappedNotFound, []error{ErrDuplicateEmail, ErrUserNotFound}, nil},
+		{"unlisted error passes through", wrappedUnauthorized, []error{ErrUserNotFound}, wrappedUnauthorized},
+		{"empty list passes through", wrappedNotFound, nil, wrappedNotFound},
+		{"nil input stays nil", nil, []error{ErrUserNotFound}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := Ignore(tt.err, tt.sentinels...); result != tt.expected {
+				t.Errorf("Ignore(%v) = %v; want %v", tt.err, result, tt.expected)
+			}
+		})
+	}
+}
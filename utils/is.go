@@ -0,0 +1,28 @@
+package utils
+
+import "errors"
+
+// IsAny reports whether err matches at least one of targets with
+// errors.Is, which searches every branch of joined errors. It is false
+// when no targets are given.
+func IsAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsAll reports whether err matches every one of targets with errors.Is.
+// The targets may be found in different branches of a joined error, e.g.
+// both halves of errors.Join(ErrValidation, ErrDatabaseTimeout). It is
+// true when no targets are given.
+func IsAll(err error, targets ...error) bool {
+	for _, target := range targets {
+		if !errors.Is(err, target) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestIsAny(t *testing.T) {
+	authErrors := []error{ErrUnauthorized, ErrInvalidPassword}
+
+	tests := []struct {
+		name     string
+		err      error
+		targets  []error
+		expected bool
+	}{
+		{"wrapped match", fmt.Errorf("login: %w", ErrInvalidPassword), authErrors, true},
+		{"joined match", errors.Join(ErrValidation, ErrUnauthorized), authErrors, true},
+		{"no match", ErrUserNotFound, authErrors, false},
+		{"no targets", ErrUnauthorized, nil, false},
+		{"nil error", nil, authErrors, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAny(tt.err, tt.targets...); got != tt.expected {
+				t.Errorf("IsAny(%v, %v) = %v; want %v", tt.err, tt.targets, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsAll(t *testing.T) {
+	joined := errors.Join(
+		fmt.Errorf("validate: %w", ErrValidation),
+		errors.Join(errors.New("audit failed"), fmt.Errorf("save: %w", ErrDatabaseTimeout)),
+	)
+
+	tests := []struct {
+		name     string
+		err      error
+		targets  []error
+		expected bool
+	}{
+		{"every branch", joined, []error{ErrValidation, ErrDatabaseTimeout}, true},
+		{"one missing", joined, []error{ErrValidation, ErrUnauthorized}, false},
+		{"single target", fmt.Errorf("find: %w", ErrUserNotFound), []error{ErrUserNotFound}, true},
+		{"no targets", joined, nil, true},
+		{"nil error", nil, []error{ErrValidation}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAll(tt.err, tt.targets...); got != tt.expected {
+				t.Errorf("IsAll(%v, %v) = %v; want %v", tt.err, tt.targets, got, tt.expected)
+			}
+		})
+	}
+}
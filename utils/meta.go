@@ -0,0 +1,89 @@
+package utils
+
+import "errors"
+
+// DocsBaseURL prefixes the documentation links of the package sentinels.
+// It matches the problem type URIs served by the problem package.
+const DocsBaseURL = "https://github.com/anwarul/go-error-handling/problems/"
+
+// Metadata describes how a sentinel should be treated by logging and
+// transport layers, so they can decide without switching on each error.
+type Metadata struct {
+	// UserSafe reports whether the message may be shown to end users.
+	UserSafe bool
+	// Retryable reports whether repeating the operation may succeed.
+	Retryable bool
+	// DocsURL links to documentation for the error.
+	DocsURL string
+	// UserMessage is what UserMessage shows end users for the error.
+	UserMessage string
+}
+
+type sentinelMetadata struct {
+	err  error
+	meta Metadata
+}
+
+// metadata is the sentinel metadata registry, searched in order.
+var metadata = []sentinelMetadata{
+	{ErrUserNotFound, Metadata{
+		UserSafe: true, DocsURL: DocsBaseURL + "user-not-found",
+		UserMessage: "We couldn't find that user.",
+	}},
+	{ErrDuplicateEmail, Metadata{
+		UserSafe: true, DocsURL: DocsBaseURL + "duplicate-email",
+		UserMessage: "An account with this email already exists.",
+	}},
+	{ErrInvalidPassword, Metadata{
+		UserSafe: true, DocsURL: DocsBaseURL + "invalid-password",
+		UserMessage: "The password is incorrect.",
+	}},
+	{ErrUnauthorized, Metadata{
+		UserSafe: true, DocsURL: DocsBaseURL + "unauthorized",
+		UserMessage: "You are not allowed to do that.",
+	}},
+	{ErrDatabaseTimeout, Metadata{
+		Retryable: true, DocsURL: DocsBaseURL + "database-timeout",
+		UserMessage: "The service is busy. Please try again.",
+	}},
+	{ErrValidation, Metadata{
+		UserSafe: true, DocsURL: DocsBaseURL + "validation-error",
+		UserMessage: "Some of the information you entered is invalid.",
+	}},
+}
+
+// RegisterMeta attaches meta to sentinel, replacing any earlier entry for
+// it. Register during init; the registry is not safe for concurrent use.
+func RegisterMeta(sentinel error, meta Metadata) {
+	for i, entry := range metadata {
+		if entry.err == sentinel {
+			metadata[i].meta = meta
+			return
+		}
+	}
+	metadata = append(metadata, sentinelMetadata{sentinel, meta})
+}
+
+// registeredMeta returns the metadata registered for sentinel itself,
+// ignoring anything it wraps or matches through an Is method.
+func registeredMeta(sentinel error) Metadata {
+	for _, entry := range metadata {
+		if entry.err == sentinel {
+			return entry.meta
+		}
+	}
+	return Metadata{}
+}
+
+// Meta returns the metadata of the first registered sentinel that err
+// matches with errors.Is. The zero Metadata and false mean err matches
+// none, in which case callers should treat it as neither safe to show
+// nor retryable.
+func Meta(err error) (Metadata, bool) {
+	for _, entry := range metadata {
+		if errors.Is(err, entry.err) {
+			return entry.meta, true
+		}
+	}
+	return Metadata{}, false
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestMeta(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected Metadata
+		found    bool
+	}{
+		{"user not found", fmt.Errorf("find: %w", ErrUserNotFound), Metadata{UserSafe: true, DocsURL: DocsBaseURL + "user-not-found", UserMessage: "We couldn't find that user."}, true},
+		{"database timeout", ErrDatabaseTimeout, Metadata{Retryable: true, DocsURL: DocsBaseURL + "database-timeout", UserMessage: "The service is busy. Please try again."}, true},
+		{"joined", errors.Join(errors.New("audit failed"), ErrUnauthorized), Metadata{UserSafe: true, DocsURL: DocsBaseURL + "unauthorized", UserMessage: "You are not allowed to do that."}, true},
+		{"unknown", errors.New("boom"), Metadata{}, false},
+		{"nil", nil, Metadata{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			meta, found := Meta(tt.err)
+			if meta != tt.expected || found != tt.found {
+				t.Errorf("Meta(%v) = %+v, %v; want %+v, %v", tt.err, meta, found, tt.expected, tt.found)
+			}
+		})
+	}
+}
+
+func TestMeta_CoversEverySentinel(t *testing.T) {
+	for _, sentinel := range sentinels {
+		if _, found := Meta(sentinel); !found {
+			t.Errorf("Meta(%v) not found; every sentinel needs metadata", sentinel)
+		}
+	}
+}
+
+func TestRegisterMeta(t *testing.T) {
+	original := slices.Clone(metadata)
+	defer func() { metadata = original }()
+
+	errQuotaExceeded := errors.New("quota exceeded")
+	RegisterMeta(errQuotaExceeded, Metadata{UserSafe: true, Retryable: true})
+	RegisterMeta(ErrUserNotFound, Metadata{})
+
+	if meta, found := Meta(fmt.Errorf("upload: %w", errQuotaExceeded)); !found || !meta.Retryable || !meta.UserSafe {
+		t.Errorf("Meta(registered) = %+v, %v; want the registered metadata", meta, found)
+	}
+	if meta, found := Meta(ErrUserNotFound); !found || meta != (Metadata{}) {
+		t.Errorf("Meta(replaced) = %+v, %v; want the replacement", meta, found)
+	}
+	if len(metadata) != len(original)+1 {
+		t.Errorf("len(metadata) = %d; want replacing an entry not to append", len(metadata))
+	}
+}
@@ -0,0 +1,21 @@
+package utils
+
+import "fmt"
+
+// PermissionError reports an authorization failure with who tried to do
+// what to which resource. It unwraps to ErrUnauthorized, so existing
+// errors.Is checks keep working, while HTTPStatus reports it as 403
+// Forbidden rather than the sentinel's 401.
+type PermissionError struct {
+	Subject  string
+	Action   string
+	Resource string
+}
+
+func (e *PermissionError) Error() string {
+	return fmt.Sprintf("%s may not %s %s: %v", e.Subject, e.Action, e.Resource, ErrUnauthorized)
+}
+
+func (e *PermissionError) Unwrap() error {
+	return ErrUnauthorized
+}
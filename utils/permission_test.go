@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestPermissionError(t *testing.T) {
+	err := fmt.Errorf("cancel order: %w", &PermissionError{Subject: "user:7", Action: "cancel", Resource: "order:42"})
+
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Error("PermissionError should match ErrUnauthorized")
+	}
+	var permErr *PermissionError
+	if !errors.As(err, &permErr) || permErr.Subject != "user:7" {
+		t.Errorf("errors.As(PermissionError) = %+v; want the subject", permErr)
+	}
+	if expected := "cancel order: user:7 may not cancel order:42: unauthorized access"; err.Error() != expected {
+		t.Errorf("Error() = %q; want %q", err.Error(), expected)
+	}
+}
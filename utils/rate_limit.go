@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// RateLimitError reports that a caller exhausted its request quota. Limit is
+// the quota per window, Remaining what is left of it (usually zero) and
+// ResetAt when the window restarts. HTTPStatus reports it as 429.
+type RateLimitError struct {
+	Limit     int
+	Remaining int
+	ResetAt   time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit of %d exceeded (%d remaining), resets at %s",
+		e.Limit, e.Remaining, e.ResetAt.Format(time.RFC3339))
+}
+
+// RetryAfter is how long the caller should wait before trying again: the
+// time until ResetAt, or zero once it has passed.
+func (e *RateLimitError) RetryAfter() time.Duration {
+	return max(time.Until(e.ResetAt), 0)
+}
+
+// IsRateLimited reports whether err carries a RateLimitError.
+func IsRateLimited(err error) bool {
+	var rateLimitErr *RateLimitError
+	return errors.As(err, &rateLimitErr)
+}
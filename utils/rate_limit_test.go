@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRateLimitError(t *testing.T) {
+	resetAt := time.Now().Add(time.Minute)
+	err := fmt.Errorf("search: %w", &RateLimitError{Limit: 100, Remaining: 0, ResetAt: resetAt})
+
+	if !IsRateLimited(err) {
+		t.Error("IsRateLimited should find the wrapped RateLimitError")
+	}
+	if IsRateLimited(errors.New("boom")) || IsRateLimited(nil) {
+		t.Error("IsRateLimited should be false for other errors")
+	}
+	if !strings.Contains(err.Error(), "rate limit of 100 exceeded (0 remaining)") {
+		t.Errorf("Error() = %q; want the limit and remaining quota", err.Error())
+	}
+
+	var rateLimitErr *RateLimitError
+	errors.As(err, &rateLimitErr)
+	if after := rateLimitErr.RetryAfter(); after <= 58*time.Second || after > time.Minute {
+		t.Errorf("RetryAfter() = %v; want about a minute", after)
+	}
+	if after := (&RateLimitError{ResetAt: time.Now().Add(-time.Second)}).RetryAfter(); after != 0 {
+		t.Errorf("RetryAfter() after reset = %v; want 0", after)
+	}
+}
@@ -0,0 +1,55 @@
+package utils
+
+import "strings"
+
+// ReplaceRoot rebuilds err's chain with newRoot as the deepest cause while
+// keeping every outer level's message text. It is meant for fault injection
+// in tests. Only single-error Unwrap chains are followed; a joined error is
+// treated as the root and replaced whole.
+//
+// Each outer level is rebuilt as a message-only wrapper, so it must read
+// "<text><cause's message>", as fmt.Errorf("...: %w") does. A level that
+// embeds its cause's message anywhere else, such as a DatabaseError,
+// cannot be rebuilt without repeating the old root, so ReplaceRoot returns
+// err unchanged; callers can check errors.Is(result, newRoot). Rebuilt
+// levels keep their text but not their type: errors.As no longer finds
+// typed wrappers above the root.
+func ReplaceRoot(err, newRoot error) error {
+	if err == nil {
+		return nil
+	}
+
+	var chain []error
+	for current := err; current != nil; {
+		chain = append(chain, current)
+		unwrapper, ok := current.(interface{ Unwrap() error })
+		if !ok {
+			break
+		}
+		current = unwrapper.Unwrap()
+	}
+
+	result := newRoot
+	for i := len(chain) - 2; i >= 0; i-- {
+		msg := chain[i].Error()
+		childMsg := chain[i+1].Error()
+		if !strings.HasSuffix(msg, childMsg) {
+			return err
+		}
+		result = &rewrappedError{prefix: strings.TrimSuffix(msg, childMsg), err: result}
+	}
+	return result
+}
+
+type rewrappedError struct {
+	prefix string
+	err    error
+}
+
+func (e *rewrappedError) Error() string {
+	return e.prefix + e.err.Error()
+}
+
+func (e *rewrappedError) Unwrap() error {
+	return e.err
+}
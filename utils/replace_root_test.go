@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/database"
+	"go-error-handling/wrapping"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestReplaceRoot_ProcessUserData(t *testing.T) {
+	original := wrapping.ProcessUserData(999)
+	if !errors.Is(original, os.ErrNotExist) {
+		t.Fatalf("precondition: ProcessUserData(999) should wrap os.ErrNotExist, got %v", original)
+	}
+
+	errInjected := errors.New("injected fault")
+	result := ReplaceRoot(original, errInjected)
+
+	if !errors.Is(result, errInjected) {
+		t.Error("ReplaceRoot result should match the new root")
+	}
+	if errors.Is(result, os.ErrNotExist) {
+		t.Error("ReplaceRoot result should no longer match os.ErrNotExist")
+	}
+
+	expectedComponents := []string{
+		"failed to process user 999",
+		"failed to load config for user 999",
+		"failed to read config file user_999.json",
+		"injected fault",
+	}
+	for _, component := range expectedComponents {
+		if !strings.Contains(result.Error(), component) {
+			t.Errorf("ReplaceRoot result should contain '%s', got: %s", component, result.Error())
+		}
+	}
+	if !strings.HasSuffix(result.Error(), ": injected fault") {
+		t.Errorf("ReplaceRoot result should end with the new root, got: %s", result.Error())
+	}
+}
+
+func TestReplaceRoot_PreservesMessages(t *testing.T) {
+	err := fmt.Errorf("handler: %w", fmt.Errorf("service: %w", ErrUserNotFound))
+
+	result := ReplaceRoot(err, ErrUnauthorized)
+
+	expected := "handler: service: unauthorized access"
+	if result.Error() != expected {
+		t.Errorf("ReplaceRoot() = %q; want %q", result.Error(), expected)
+	}
+	if errors.Is(result, ErrUserNotFound) {
+		t.Error("ReplaceRoot result should not match the replaced root")
+	}
+}
+
+func TestReplaceRoot_EdgeCases(t *testing.T) {
+	if result := ReplaceRoot(nil, ErrUnauthorized); result != nil {
+		t.Errorf("ReplaceRoot(nil) = %v; want nil", result)
+	}
+	if result := ReplaceRoot(ErrUserNotFound, ErrUnauthorized); result != ErrUnauthorized {
+		t.Errorf("ReplaceRoot(bare sentinel) = %v; want the new root", result)
+	}
+}
+
+func TestReplaceRoot_TypedIntermediates(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// DatabaseError's message embeds its cause mid-text, so rebuilding it
+	// as a prefix would keep "boom" after the replacement.
+	dbErr := &database.DatabaseError{Operation: "SELECT", Table: "users", Err: errBoom}
+	original := fmt.Errorf("outer: %w", dbErr)
+	if result := ReplaceRoot(original, ErrUnauthorized); result != original {
+		t.Errorf("ReplaceRoot(DatabaseError chain) = %v; want err unchanged", result)
+	}
+
+	// A typed level whose message ends with its cause's is rebuilt by text
+	// only, as documented.
+	perm := &PermissionError{Subject: "u1", Action: "read", Resource: "report"}
+	result := ReplaceRoot(fmt.Errorf("outer: %w", perm), ErrUserNotFound)
+	var target *PermissionError
+	if errors.As(result, &target) {
+		t.Error("rebuilt levels should not keep their type")
+	}
+	if !errors.Is(result, ErrUserNotFound) {
+		t.Errorf("ReplaceRoot() = %v; want the new root", result)
+	}
+}
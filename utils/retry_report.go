@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"fmt"
+	"go-error-handling/database"
+	"strings"
+)
+
+// RetryReport renders one line per error with its retry verdict, delay and
+// reason, e.g. "[0] retryable=true delay=2s reason=transient database error".
+// It is meant for operators triaging a stuck queue.
+func RetryReport(errs []error) string {
+	lines := make([]string, 0, len(errs))
+	for i, err := range errs {
+		retry, delay := database.RetryDecision(err)
+		lines = append(lines, fmt.Sprintf("[%d] retryable=%v delay=%v reason=%s",
+			i, retry, delay, database.ExplainRetryable(err)))
+	}
+	return strings.Join(lines, "\n")
+}
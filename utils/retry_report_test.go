@@ -0,0 +1,40 @@
+package utils
+
+import (
+	"errors"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryReport(t *testing.T) {
+	errs := []error{
+		&database.DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("connection timeout"), Timestamp: time.Now(), Retryable: true},
+		&custom.ValidationError{Field: "Age", Message: "Age cannot be negative", Code: 2001, Value: -1},
+	}
+
+	report := RetryReport(errs)
+	lines := strings.Split(report, "\n")
+
+	if len(lines) != 2 {
+		t.Fatalf("RetryReport produced %d lines; want 2:\n%s", len(lines), report)
+	}
+
+	expected := []string{
+		"[0] retryable=true delay=2s reason=transient database error",
+		"[1] retryable=false delay=0s reason=not a database error",
+	}
+	for i, line := range expected {
+		if lines[i] != line {
+			t.Errorf("line %d = %q; want %q", i, lines[i], line)
+		}
+	}
+}
+
+func TestRetryReport_Empty(t *testing.T) {
+	if report := RetryReport(nil); report != "" {
+		t.Errorf("RetryReport(nil) = %q; want empty", report)
+	}
+}
@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sync/atomic"
+)
+
+// sentinelIDs maps stable "namespace.name" IDs to sentinels so services can
+// refer to errors by string, e.g. in configuration or across APIs.
+var sentinelIDs = map[string]error{
+	"user.not_found":        ErrUserNotFound,
+	"user.duplicate_email":  ErrDuplicateEmail,
+	"auth.invalid_password": ErrInvalidPassword,
+	"auth.unauthorized":     ErrUnauthorized,
+	"database.timeout":      ErrDatabaseTimeout,
+	"validation.failed":     ErrValidation,
+}
+
+// sentinelError is a sentinel created by NewSentinel or
+// NewDeprecatedSentinel. It exists alongside ConstError because ConstErrors
+// are identified by message: two packages declaring "quota exceeded" would
+// get one error. A sentinelError is compared by pointer, so sentinels with
+// the same message in different namespaces stay distinct under errors.Is.
+// The package's own sentinels stay ConstErrors so they can be constants.
+type sentinelError struct {
+	id  string
+	msg string
+	// replacement is set for deprecated sentinels.
+	replacement error
+	// warned records that OnDeprecatedSentinel has reported this sentinel.
+	warned atomic.Bool
+}
+
+func (e *sentinelError) Error() string {
+	return e.msg
+}
+
+// ID returns the sentinel's "namespace.name" ID.
+func (e *sentinelError) ID() string {
+	return e.id
+}
+
+// NewSentinel returns a new sentinel error registered under the ID
+// "namespace.name", where Lookup can find it, and adds it to the sentinels
+// checked by CheckSentinelStyle. Sentinels are declared at package level,
+// where an error cannot be handled, so NewSentinel panics if namespace or
+// name is empty or the ID is already taken.
+func NewSentinel(namespace, name, message string) error {
+	return registerSentinel(namespace, name, &sentinelError{msg: message})
+}
+
+func registerSentinel(namespace, name string, err *sentinelError) *sentinelError {
+	err.id = namespace + "." + name
+	if namespace == "" || name == "" {
+		panic(fmt.Sprintf("utils: sentinel %q needs both a namespace and a name", err.id))
+	}
+	if _, taken := sentinelIDs[err.id]; taken {
+		panic(fmt.Sprintf("utils: sentinel %q is already registered", err.id))
+	}
+	sentinelIDs[err.id] = err
+	sentinels = append(sentinels, err)
+	return err
+}
+
+// Lookup returns the sentinel registered under id, such as
+// "auth.unauthorized", and whether there is one. err is the sentinel
+// itself, not a failure: check ok.
+func Lookup(id string) (err error, ok bool) {
+	err, ok = sentinelIDs[id]
+	return err, ok
+}
+
+// SentinelIDs returns the IDs of every registered sentinel in sorted order.
+func SentinelIDs() []string {
+	return slices.Sorted(maps.Keys(sentinelIDs))
+}
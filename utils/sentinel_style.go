@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// CheckSentinelStyle returns the sentinels whose messages break Go error
+// string conventions: starting with an uppercase letter or ending with
+// punctuation. Such messages read badly once wrapped mid-sentence.
+func CheckSentinelStyle() []error {
+	var violations []error
+	for _, err := range sentinels {
+		if !hasConventionalMessage(err.Error()) {
+			violations = append(violations, err)
+		}
+	}
+	return violations
+}
+
+func hasConventionalMessage(msg string) bool {
+	if msg == "" {
+		return true
+	}
+	first, _ := utf8.DecodeRuneInString(msg)
+	last, _ := utf8.DecodeLastRuneInString(msg)
+	return !unicode.IsUpper(first) && !unicode.IsPunct(last)
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCheckSentinelStyle_ExistingSentinels(t *testing.T) {
+	if violations := CheckSentinelStyle(); len(violations) != 0 {
+		t.Errorf("CheckSentinelStyle() = %v; want no violations", violations)
+	}
+}
+
+func TestCheckSentinelStyle_DetectsViolations(t *testing.T) {
+	original := sentinels
+	defer func() { sentinels = original }()
+
+	errCapitalized := errors.New("Account locked")
+	errPunctuated := errors.New("session expired.")
+	sentinels = append(append([]error{}, original...), errCapitalized, errPunctuated)
+
+	violations := CheckSentinelStyle()
+	if len(violations) != 2 {
+		t.Fatalf("CheckSentinelStyle() found %d violations; want 2: %v", len(violations), violations)
+	}
+	if violations[0] != errCapitalized || violations[1] != errPunctuated {
+		t.Errorf("CheckSentinelStyle() = %v; want [%v %v]", violations, errCapitalized, errPunctuated)
+	}
+}
+
+func TestHasConventionalMessage(t *testing.T) {
+	tests := []struct {
+		msg      string
+		expected bool
+	}{
+		{"user not found", true},
+		{"", true},
+		{"User not found", false},
+		{"user not found!", false},
+		{"user not found.", false},
+	}
+
+	for _, tt := range tests {
+		if got := hasConventionalMessage(tt.msg); got != tt.expected {
+			t.Errorf("hasConventionalMessage(%q) = %v; want %v", tt.msg, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"testing"
+)
+
+// restoreSentinels undoes the registrations a test makes.
+func restoreSentinels(t *testing.T) {
+	ids, list := maps.Clone(sentinelIDs), sentinels
+	t.Cleanup(func() { sentinelIDs, sentinels = ids, list })
+}
+
+func TestNewSentinel(t *testing.T) {
+	restoreSentinels(t)
+
+	errQuota := NewSentinel("billing", "quota_exceeded", "quota exceeded")
+	errOtherQuota := NewSentinel("storage", "quota_exceeded", "quota exceeded")
+
+	if errQuota.Error() != "quota exceeded" {
+		t.Errorf("Error() = %q; want %q", errQuota.Error(), "quota exceeded")
+	}
+	if errors.Is(errQuota, errOtherQuota) {
+		t.Error("sentinels in different namespaces should be distinct")
+	}
+	if id := errQuota.(interface{ ID() string }).ID(); id != "billing.quota_exceeded" {
+		t.Errorf("ID() = %q; want billing.quota_exceeded", id)
+	}
+
+	found, ok := Lookup("billing.quota_exceeded")
+	if !ok || !errors.Is(fmt.Errorf("upload: %w", errQuota), found) {
+		t.Errorf("Lookup(billing.quota_exceeded) = %v, %v; want the new sentinel", found, ok)
+	}
+	if last := sentinels[len(sentinels)-1]; last != errOtherQuota {
+		t.Errorf("sentinels ends with %v; want new sentinels added for style checks", last)
+	}
+}
+
+func TestNewSentinel_Panics(t *testing.T) {
+	tests := []struct {
+		name            string
+		namespace, slug string
+	}{
+		{"duplicate", "auth", "unauthorized"},
+		{"empty namespace", "", "locked"},
+		{"empty name", "auth", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restoreSentinels(t)
+			defer func() {
+				if recover() == nil {
+					t.Errorf("NewSentinel(%q, %q) should panic", tt.namespace, tt.slug)
+				}
+			}()
+			NewSentinel(tt.namespace, tt.slug, "account locked")
+		})
+	}
+}
+
+func TestLookup_BuiltinSentinels(t *testing.T) {
+	if err, ok := Lookup("auth.unauthorized"); !ok || err != ErrUnauthorized {
+		t.Errorf("Lookup(auth.unauthorized) = %v, %v; want ErrUnauthorized", err, ok)
+	}
+	if ids := SentinelIDs(); len(ids) != len(sentinelIDs) || !slices.IsSorted(ids) || !slices.Contains(ids, "user.not_found") {
+		t.Errorf("SentinelIDs() = %v; want every ID, sorted", ids)
+	}
+	if err, ok := Lookup("auth.missing"); ok || err != nil {
+		t.Errorf("Lookup(auth.missing) = %v, %v; want nil, false", err, ok)
+	}
+	for _, sentinel := range sentinels {
+		if !slices.Contains(slices.Collect(maps.Values(sentinelIDs)), sentinel) {
+			t.Errorf("sentinel %v has no ID", sentinel)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"go-error-handling/wrapping"
+	"sync"
+)
+
+// stringCodes holds the sentinels added with RegisterStringCode. The
+// package sentinels take their string code from Code.String, which is the
+// API error code contract: the frontend branches on these strings, so
+// they must never be renamed once published.
+var stringCodes struct {
+	sync.RWMutex
+	entries []stringCodeEntry
+}
+
+type stringCodeEntry struct {
+	err  error
+	code string
+}
+
+// RegisterStringCode adds a sentinel to the string code registry. It is
+// safe to call while StringCode is resolving codes on other goroutines.
+func RegisterStringCode(sentinel error, code string) {
+	stringCodes.Lock()
+	defer stringCodes.Unlock()
+	stringCodes.entries = append(stringCodes.entries, stringCodeEntry{sentinel, code})
+}
+
+// StringCode resolves the machine-readable code for the outermost error in
+// err's chain that has one. Validation errors map to "VALIDATION_<code>" and
+// database errors to "DATABASE_ERROR". The chain is searched in
+// wrapping.All order, so a chain that wraps itself cannot hang it.
+func StringCode(err error) (string, bool) {
+	for e := range wrapping.All(err) {
+		if code, ok := stringCodeOf(e); ok {
+			return code, true
+		}
+	}
+	return "", false
+}
+
+func stringCodeOf(err error) (string, bool) {
+	switch e := err.(type) {
+	case *custom.ValidationError:
+		return fmt.Sprintf("VALIDATION_%d", e.Code), true
+	case *database.DatabaseError:
+		return "DATABASE_ERROR", true
+	}
+	if code, ok := registeredStringCode(err); ok {
+		return code, true
+	}
+	if coded, ok := err.(interface{ Code() Code }); ok {
+		if code := coded.Code(); code != CodeUnknown {
+			return code.String(), true
+		}
+	}
+	return "", false
+}
+
+// registeredStringCode looks err up in the RegisterStringCode registry.
+func registeredStringCode(err error) (string, bool) {
+	stringCodes.RLock()
+	defer stringCodes.RUnlock()
+	for _, entry := range stringCodes.entries {
+		if err == entry.err {
+			return entry.code, true
+		}
+	}
+	return "", false
+}
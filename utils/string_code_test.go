@@ -0,0 +1,93 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"go-error-handling/internal/errtest"
+	"testing"
+	"time"
+)
+
+func TestStringCode(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		expectedCode string
+	}{
+		{"wrapped ErrUserNotFound", fmt.Errorf("lookup failed: %w", ErrUserNotFound), "USER_NOT_FOUND"},
+		{"bare ErrUnauthorized", ErrUnauthorized, "UNAUTHORIZED"},
+		{
+			"validation error",
+			&custom.ValidationError{Field: "Age", Message: "Age cannot be negative", Code: 2001, Value: -1},
+			"VALIDATION_2001",
+		},
+		{
+			"wrapped database error",
+			fmt.Errorf("query: %w", &database.DatabaseError{Operation: "SELECT", Table: "users", Err: ErrDatabaseTimeout, Timestamp: time.Now()}),
+			"DATABASE_ERROR",
+		},
+		{"joined errors", errors.Join(errors.New("unknown"), ErrDuplicateEmail), "DUPLICATE_EMAIL"},
+		{"ErrValidation", fmt.Errorf("signup: %w", ErrValidation), "VALIDATION"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, ok := StringCode(tt.err)
+			if !ok {
+				t.Fatalf("StringCode(%v) found no code; want %s", tt.err, tt.expectedCode)
+			}
+			if code != tt.expectedCode {
+				t.Errorf("StringCode(%v) = %s; want %s", tt.err, code, tt.expectedCode)
+			}
+		})
+	}
+}
+
+func TestStringCode_Unknown(t *testing.T) {
+	for _, err := range []error{errors.New("unknown"), nil} {
+		if code, ok := StringCode(err); ok {
+			t.Errorf("StringCode(%v) = %s; want no code", err, code)
+		}
+	}
+}
+
+func TestRegisterStringCode(t *testing.T) {
+	stringCodes.Lock()
+	original := stringCodes.entries
+	stringCodes.Unlock()
+	defer func() {
+		stringCodes.Lock()
+		stringCodes.entries = original
+		stringCodes.Unlock()
+	}()
+
+	errQuotaExceeded := errors.New("quota exceeded")
+	RegisterStringCode(errQuotaExceeded, "QUOTA_EXCEEDED")
+
+	code, ok := StringCode(fmt.Errorf("upload: %w", errQuotaExceeded))
+	if !ok || code != "QUOTA_EXCEEDED" {
+		t.Errorf("StringCode(registered) = (%s, %v); want (QUOTA_EXCEEDED, true)", code, ok)
+	}
+}
+
+func TestStringCode_CyclicChain(t *testing.T) {
+	loop := &errtest.LoopError{}
+	loop.Next = fmt.Errorf("retry: %w", loop)
+	if code, ok := StringCode(loop); ok {
+		t.Errorf("StringCode(cycle) = %s; want no code", code)
+	}
+	if got := UserMessage(loop); got != GenericUserMessage {
+		t.Errorf("UserMessage(cycle) = %q; want %q", got, GenericUserMessage)
+	}
+}
+
+func TestStringCode_MatchesCodeOf(t *testing.T) {
+	for _, sentinel := range []error{ErrUserNotFound, ErrDuplicateEmail, ErrInvalidPassword, ErrUnauthorized, ErrDatabaseTimeout, ErrValidation} {
+		code, ok := StringCode(sentinel)
+		if want := CodeOf(sentinel).String(); !ok || code != want {
+			t.Errorf("StringCode(%v) = (%s, %v); want (%s, true)", sentinel, code, ok, want)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package utils
+
+// Tag marks err as an instance of sentinel without changing its message,
+// so a low-level error such as sql.ErrNoRows can be promoted to a domain
+// sentinel such as ErrUserNotFound. errors.Is and errors.As search both the
+// original chain and the sentinel. It returns nil when err is nil and err
+// unchanged when sentinel is nil.
+func Tag(err, sentinel error) error {
+	if err == nil {
+		return nil
+	}
+	if sentinel == nil {
+		return err
+	}
+	return &taggedError{err: err, sentinel: sentinel}
+}
+
+type taggedError struct {
+	err      error
+	sentinel error
+}
+
+func (e *taggedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *taggedError) Unwrap() []error {
+	return []error{e.err, e.sentinel}
+}
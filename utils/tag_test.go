@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestTag(t *testing.T) {
+	cause := fmt.Errorf("scan user 42: %w", sql.ErrNoRows)
+	err := fmt.Errorf("find user: %w", Tag(cause, ErrUserNotFound))
+
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Error("tagged error should still match the original cause")
+	}
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Error("tagged error should match the sentinel")
+	}
+	if errors.Is(err, ErrUnauthorized) {
+		t.Error("tagged error should not match other sentinels")
+	}
+	if err.Error() != "find user: scan user 42: sql: no rows in result set" {
+		t.Errorf("Error() = %q; want the original message unchanged", err.Error())
+	}
+	if CodeOf(err) != CodeUserNotFound {
+		t.Errorf("CodeOf() = %v; want %v", CodeOf(err), CodeUserNotFound)
+	}
+}
+
+func TestTag_Nil(t *testing.T) {
+	if err := Tag(nil, ErrUserNotFound); err != nil {
+		t.Errorf("Tag(nil, sentinel) = %v; want nil", err)
+	}
+	if err := Tag(sql.ErrNoRows, nil); err != sql.ErrNoRows {
+		t.Errorf("Tag(err, nil) = %v; want err unchanged", err)
+	}
+}
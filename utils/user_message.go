@@ -0,0 +1,43 @@
+package utils
+
+import "go-error-handling/wrapping"
+
+// GenericUserMessage is what UserMessage returns for errors that have no
+// user-facing message, so internal details never reach end users.
+const GenericUserMessage = "Something went wrong. Please try again later."
+
+// UserFacer is implemented by errors that carry a message safe to show to
+// end users, as opposed to the internal detail in Error().
+type UserFacer interface {
+	UserFacing() string
+}
+
+// UserFacing returns the UserMessage registered for the sentinel with
+// RegisterMeta, or "" if there is none. Identity is by message, so any
+// ConstError with a registered sentinel's message gets its UserMessage.
+func (e ConstError) UserFacing() string {
+	return registeredMeta(e).UserMessage
+}
+
+// UserFacing returns the UserMessage registered for the sentinel with
+// RegisterMeta, or "" if there is none.
+func (e *sentinelError) UserFacing() string {
+	return registeredMeta(e).UserMessage
+}
+
+// UserMessage returns the first non-empty UserFacing message found in
+// err's chain, outermost first, or GenericUserMessage when there is none.
+// It returns "" for nil.
+func UserMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	for e := range wrapping.All(err) {
+		if facer, ok := e.(UserFacer); ok {
+			if message := facer.UserFacing(); message != "" {
+				return message
+			}
+		}
+	}
+	return GenericUserMessage
+}
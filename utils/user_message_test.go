@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/custom"
+	"go-error-handling/database"
+	"slices"
+	"testing"
+)
+
+func TestUserMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{"nil", nil, ""},
+		{"sentinel", fmt.Errorf("lookup user 42 in shard 3: %w", ErrUserNotFound), "We couldn't find that user."},
+		{"validation error", &custom.ValidationError{Field: "Password", Message: "Password is too short", Value: "hunter2"}, "Password is too short"},
+		{"validation without message", &custom.ValidationError{Field: "Nickname", Code: 999999}, "Nickname is invalid"},
+		{"joined", errors.Join(errors.New("audit failed"), ErrDuplicateEmail), "An account with this email already exists."},
+		{"outermost wins", fmt.Errorf("%w: %w", &custom.ValidationError{Field: "Email", Message: "Email cannot be empty"}, ErrValidation), "Email cannot be empty"},
+		{"database error", &database.DatabaseError{Operation: "SELECT", Table: "users", Err: errors.New("dial tcp 10.0.0.5:5432")}, GenericUserMessage},
+		{"foreign const error", ConstError("quota exceeded"), GenericUserMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := UserMessage(tt.err); got != tt.expected {
+				t.Errorf("UserMessage(%v) = %q; want %q", tt.err, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestUserMessage_CoversEverySentinel(t *testing.T) {
+	for _, sentinel := range sentinels {
+		if UserMessage(sentinel) == GenericUserMessage {
+			t.Errorf("UserMessage(%v) is generic; every sentinel needs a user-facing message", sentinel)
+		}
+	}
+}
+
+func TestUserMessage_FromRegisteredMeta(t *testing.T) {
+	restoreSentinels(t)
+	original := slices.Clone(metadata)
+	defer func() { metadata = original }()
+
+	errQuota := NewSentinel("billing", "quota_exceeded", "quota exceeded for tenant")
+	if got := UserMessage(errQuota); got != GenericUserMessage {
+		t.Errorf("UserMessage(unregistered) = %q; want %q", got, GenericUserMessage)
+	}
+
+	RegisterMeta(errQuota, Metadata{UserMessage: "You have used up your quota."})
+	if got := UserMessage(fmt.Errorf("upload: %w", errQuota)); got != "You have used up your quota." {
+		t.Errorf("UserMessage(registered) = %q; want the registered message", got)
+	}
+}
@@ -0,0 +1,34 @@
+package utils
+
+import (
+	"fmt"
+	"runtime"
+	"strings"
+)
+
+// WrapHere wraps err with msg and the name of the calling function, giving
+// cheap attribution (e.g. "user.ValidateUser: ...") without a full stack.
+// It returns nil when err is nil.
+func WrapHere(err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %s: %w", callerName(2), msg, err)
+}
+
+// callerName returns the package-qualified function name skip frames up.
+func callerName(skip int) string {
+	pc, _, _, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+	fn := runtime.FuncForPC(pc)
+	if fn == nil {
+		return "unknown"
+	}
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func loadProfileHelper() error {
+	return WrapHere(ErrUserNotFound, "loading profile")
+}
+
+func TestWrapHere_RecordsCaller(t *testing.T) {
+	err := loadProfileHelper()
+
+	expected := "utils.loadProfileHelper: loading profile: user not found"
+	if err.Error() != expected {
+		t.Errorf("WrapHere() = %q; want %q", err.Error(), expected)
+	}
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Error("WrapHere should preserve errors.Is matching")
+	}
+}
+
+func TestWrapHere_ErrorsAs(t *testing.T) {
+	base := &wrapHereTestError{reason: "boom"}
+	err := WrapHere(base, "step failed")
+
+	var target *wrapHereTestError
+	if !errors.As(err, &target) || target != base {
+		t.Error("WrapHere should preserve errors.As extraction")
+	}
+	if !strings.Contains(err.Error(), "TestWrapHere_ErrorsAs") {
+		t.Errorf("WrapHere() should name the calling test, got: %s", err.Error())
+	}
+}
+
+func TestWrapHere_Nil(t *testing.T) {
+	if err := WrapHere(nil, "nothing to wrap"); err != nil {
+		t.Errorf("WrapHere(nil) = %v; want nil", err)
+	}
+}
+
+type wrapHereTestError struct {
+	reason string
+}
+
+func (e *wrapHereTestError) Error() string {
+	return e.reason
+}
@@ -0,0 +1,84 @@
+package wrapping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Config is a user's configuration file.
+type Config struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+}
+
+// ConfigError reports where a config file failed to parse. Err is the
+// *json.SyntaxError or *json.UnmarshalTypeError from encoding/json.
+type ConfigError struct {
+	Filename string
+	// Line and Column, both starting at 1, locate the offending byte for
+	// a syntax error, or the last byte of the offending value for a type
+	// error.
+	Line   int
+	Column int
+	// Key is the dotted path of the field whose value had the wrong type,
+	// such as "value". It is empty for syntax errors.
+	Key string
+	Err error
+}
+
+func (e *ConfigError) Error() string {
+	msg := fmt.Sprintf("failed to parse config file %s at line %d, column %d", e.Filename, e.Line, e.Column)
+	if e.Key != "" {
+		msg += fmt.Sprintf(" (key %q)", e.Key)
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// LoadConfig reads name from fsys and parses it as JSON. A read failure is
+// wrapped as "failed to read config file" and a parse failure is a
+// *ConfigError. Either way the error records its stack and carries the
+// filename as a field.
+func LoadConfig(fsys fs.FS, name string) (*Config, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, WithFields(Errorf("failed to read config file %s: %w", name, err), "filename", name)
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, WithFields(WithStack(newConfigError(name, data, err)), "filename", name)
+	}
+	return &cfg, nil
+}
+
+// newConfigError locates err, returned by json.Unmarshal for data, in
+// data. Errors without an offset are reported at line 1, column 1.
+func newConfigError(filename string, data []byte, err error) *ConfigError {
+	configErr := &ConfigError{Filename: filename, Err: err}
+
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+		configErr.Key = typeErr.Field
+	}
+
+	before := data[:min(int(offset), len(data))]
+	configErr.Line = bytes.Count(before, []byte("\n")) + 1
+	configErr.Column = len(before) - (bytes.LastIndexByte(before, '\n') + 1)
+	if configErr.Column == 0 {
+		configErr.Column = 1
+	}
+	return configErr
+}
@@ -0,0 +1,83 @@
+package wrapping
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ContextExtractor returns fields to attach to an error wrapped with
+// WrapCtx, as alternating keys and values like WithFields, or nil if ctx
+// carries nothing it knows about.
+type ContextExtractor func(ctx context.Context) []any
+
+// contextExtractors run in order; the built-in ones come first.
+var contextExtractors = []ContextExtractor{requestIDFields, userIDFields, deadlineFields}
+
+// RegisterContextExtractor adds fn to the extractors WrapCtx runs. It runs
+// after the built-in ones and those registered before it, so it can
+// override their keys. Register during init; the registry is not safe for
+// concurrent use.
+func RegisterContextExtractor(fn ContextExtractor) {
+	contextExtractors = append(contextExtractors, fn)
+}
+
+type requestIDKey struct{}
+
+type userIDKey struct{}
+
+// ContextWithRequestID returns a copy of ctx carrying the request ID that
+// WrapCtx records as "requestID". Request middleware should call it once
+// per request.
+func ContextWithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// ContextWithUserID returns a copy of ctx carrying the authenticated user
+// ID that WrapCtx records as "userID".
+func ContextWithUserID(ctx context.Context, userID int) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// WrapCtx wraps err as "msg: err" and attaches fields taken from ctx by
+// the registered extractors: by default "requestID" and "userID" when set
+// with ContextWithRequestID and ContextWithUserID, and "deadline" and
+// "timeLeft" when ctx has a deadline. Read them back with Fields. It
+// returns nil for nil.
+func WrapCtx(ctx context.Context, err error, msg string) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := fmt.Errorf("%s: %w", msg, err)
+	var kvs []any
+	for _, extract := range contextExtractors {
+		kvs = append(kvs, extract(ctx)...)
+	}
+	if len(kvs) == 0 {
+		return wrapped
+	}
+	return WithFields(wrapped, kvs...)
+}
+
+func requestIDFields(ctx context.Context) []any {
+	if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+		return []any{"requestID", id}
+	}
+	return nil
+}
+
+func userIDFields(ctx context.Context) []any {
+	if id, ok := ctx.Value(userIDKey{}).(int); ok {
+		return []any{"userID", id}
+	}
+	return nil
+}
+
+// deadlineFields reports timeLeft as a negative duration once the deadline
+// has passed.
+func deadlineFields(ctx context.Context) []any {
+	if deadline, ok := ctx.Deadline(); ok {
+		return []any{"deadline", deadline, "timeLeft", time.Until(deadline)}
+	}
+	return nil
+}
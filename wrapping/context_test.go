@@ -0,0 +1,58 @@
+package wrapping
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestWrapCtx(t *testing.T) {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	ctx = ContextWithUserID(ContextWithRequestID(ctx, "req-42"), 7)
+
+	err := WrapCtx(ctx, os.ErrNotExist, "load profile")
+	if err.Error() != "load profile: file does not exist" || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("WrapCtx() = %v; want the message prefixed and the chain kept", err)
+	}
+
+	fields := Fields(err)
+	if got, _ := fields["deadline"].(time.Time); fields["requestID"] != "req-42" || fields["userID"] != 7 || !got.Equal(deadline) {
+		t.Errorf("Fields() = %v; want the request ID, user ID and deadline from ctx", fields)
+	}
+	if left, ok := fields["timeLeft"].(time.Duration); !ok || left <= 0 || left > time.Minute {
+		t.Errorf("timeLeft = %v; want the time remaining before the deadline", fields["timeLeft"])
+	}
+}
+
+func TestWrapCtx_NoMetadata(t *testing.T) {
+	if WrapCtx(context.Background(), nil, "load") != nil {
+		t.Error("WrapCtx(nil) should be nil")
+	}
+
+	err := WrapCtx(context.Background(), os.ErrNotExist, "load")
+	if fields := Fields(err); fields != nil {
+		t.Errorf("Fields() = %v; want nil for a context without metadata", fields)
+	}
+}
+
+func TestRegisterContextExtractor(t *testing.T) {
+	defer func(saved []ContextExtractor) { contextExtractors = saved }(contextExtractors)
+
+	type tenantKey struct{}
+	RegisterContextExtractor(func(ctx context.Context) []any {
+		if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+			return []any{"tenant", tenant, "requestID", "overridden"}
+		}
+		return nil
+	})
+
+	ctx := context.WithValue(ContextWithRequestID(context.Background(), "req-1"), tenantKey{}, "acme")
+	fields := Fields(WrapCtx(ctx, os.ErrPermission, "save"))
+	if fields["tenant"] != "acme" || fields["requestID"] != "overridden" {
+		t.Errorf("Fields() = %v; want the registered extractor's fields, overriding built-in keys", fields)
+	}
+}
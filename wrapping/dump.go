@@ -0,0 +1,170 @@
+package wrapping
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"strings"
+)
+
+// Dump formats.
+const (
+	DumpTree = "tree"
+	DumpJSON = "json"
+	DumpDOT  = "dot"
+)
+
+// dumpNode is one error in the tree Dump writes.
+type dumpNode struct {
+	Type     string         `json:"type"`
+	Message  string         `json:"message"`
+	Fields   map[string]any `json:"fields,omitempty"`
+	Location *dumpFrame     `json:"location,omitempty"`
+	Stack    []dumpFrame    `json:"stack,omitempty"`
+	Causes   []*dumpNode    `json:"causes,omitempty"`
+}
+
+type dumpFrame struct {
+	Function string `json:"function"`
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+}
+
+// Dump writes every error in err's tree, including this package's
+// wrappers, with its Go type, message, and any fields, Here location and
+// stack it records. format is DumpTree for an indented text tree, DumpJSON
+// for nested objects with the causes of each error under "causes", or
+// DumpDOT for a Graphviz digraph with an edge from each error to its
+// causes. Errors beneath a Redact wrapper are left out, and a chain Walk
+// cannot finish ends with an *ErrChainTooDeep node. Dump writes nothing for
+// nil.
+func Dump(err error, w io.Writer, format string) error {
+	root := buildDumpTree(err)
+	var b strings.Builder
+	switch format {
+	case DumpTree:
+		if root != nil {
+			writeDumpTree(&b, root, "", "")
+		}
+	case DumpJSON:
+		if root != nil {
+			data, jsonErr := json.MarshalIndent(root, "", "  ")
+			if jsonErr != nil {
+				return fmt.Errorf("failed to encode error tree: %w", jsonErr)
+			}
+			b.Write(data)
+			b.WriteString("\n")
+		}
+	case DumpDOT:
+		if root != nil {
+			b.WriteString("digraph errors {\n\tnode [shape=box];\n")
+			writeDumpDOT(&b, root, new(int))
+			b.WriteString("}\n")
+		}
+	default:
+		return fmt.Errorf("unknown dump format %q", format)
+	}
+	_, writeErr := io.WriteString(w, b.String())
+	return writeErr
+}
+
+// buildDumpTree converts err's tree into dumpNodes, or returns nil for nil.
+func buildDumpTree(err error) *dumpNode {
+	var root *dumpNode
+	// parents[d] is the node most recently visited at depth d.
+	var parents []*dumpNode
+	attach := func(n *dumpNode, depth int) {
+		if depth == 0 {
+			root = n
+		} else {
+			parents[depth-1].Causes = append(parents[depth-1].Causes, n)
+		}
+		parents = append(parents[:depth], n)
+	}
+	walkErr := walkRendered(err, func(e error, depth int) bool {
+		n := &dumpNode{Type: fmt.Sprintf("%T", e), Message: e.Error()}
+		switch x := e.(type) {
+		case *fieldsError:
+			n.Fields = x.fields
+		case *locationError:
+			frame, _ := runtime.CallersFrames([]uintptr{x.pc}).Next()
+			n.Location = &dumpFrame{frame.Function, frame.File, frame.Line}
+		case *stackError:
+			if x.stack != nil {
+				frames := runtime.CallersFrames(x.stack)
+				for {
+					frame, more := frames.Next()
+					n.Stack = append(n.Stack, dumpFrame{frame.Function, frame.File, frame.Line})
+					if !more {
+						break
+					}
+				}
+			}
+		}
+		attach(n, depth)
+		return true
+	})
+	if tooDeep, ok := walkErr.(*ErrChainTooDeep); ok {
+		attach(&dumpNode{Type: fmt.Sprintf("%T", tooDeep), Message: tooDeep.Error()}, tooDeep.Depth)
+	}
+	return root
+}
+
+// writeDumpTree writes n on one line after prefix, then its annotations and
+// causes on lines starting with childPrefix.
+func writeDumpTree(b *strings.Builder, n *dumpNode, prefix, childPrefix string) {
+	fmt.Fprintf(b, "%s%s %q", prefix, n.Type, n.Message)
+	if len(n.Fields) > 0 {
+		b.WriteString(" " + formatFields(n.Fields))
+	}
+	b.WriteString("\n")
+
+	detail := childPrefix + "│ "
+	if len(n.Causes) == 0 {
+		detail = childPrefix + "  "
+	}
+	if n.Location != nil {
+		fmt.Fprintf(b, "%sat %s (%s:%d)\n", detail, n.Location.Function, n.Location.File, n.Location.Line)
+	}
+	for _, frame := range n.Stack {
+		fmt.Fprintf(b, "%s%s\n%s\t%s:%d\n", detail, frame.Function, detail, frame.File, frame.Line)
+	}
+
+	for i, cause := range n.Causes {
+		if i == len(n.Causes)-1 {
+			writeDumpTree(b, cause, childPrefix+"└─ ", childPrefix+"   ")
+		} else {
+			writeDumpTree(b, cause, childPrefix+"├─ ", childPrefix+"│  ")
+		}
+	}
+}
+
+// writeDumpDOT writes n and its causes as DOT statements, numbering nodes
+// from *next, and returns n's node ID.
+func writeDumpDOT(b *strings.Builder, n *dumpNode, next *int) string {
+	id := fmt.Sprintf("n%d", *next)
+	*next++
+
+	lines := []string{n.Type, n.Message}
+	if len(n.Fields) > 0 {
+		lines = append(lines, formatFields(n.Fields))
+	}
+	if n.Location != nil {
+		lines = append(lines, fmt.Sprintf("at %s (%s:%d)", n.Location.Function, n.Location.File, n.Location.Line))
+	}
+	for _, frame := range n.Stack {
+		lines = append(lines, fmt.Sprintf("%s (%s:%d)", frame.Function, frame.File, frame.Line))
+	}
+	fmt.Fprintf(b, "\t%s [label=\"%s\"];\n", id, dotEscape(strings.Join(lines, "\n")))
+
+	for _, cause := range n.Causes {
+		fmt.Fprintf(b, "\t%s -> %s;\n", id, writeDumpDOT(b, cause, next))
+	}
+	return id
+}
+
+// dotEscape escapes s for a double-quoted DOT string.
+func dotEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
@@ -0,0 +1,81 @@
+package wrapping
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"go-error-handling/internal/errtest"
+	"strings"
+	"testing"
+)
+
+func TestDump_Tree(t *testing.T) {
+	err := errors.Join(WithFields(errors.New("disk full"), "disk", "sda"), errors.New("audit failed"))
+
+	var buf bytes.Buffer
+	if dumpErr := Dump(err, &buf, DumpTree); dumpErr != nil {
+		t.Fatalf("Dump() error = %v", dumpErr)
+	}
+	expected := "*errors.joinError \"disk full\\naudit failed\"\n" +
+		"├─ *wrapping.fieldsError \"disk full\" {disk=sda}\n" +
+		"│  └─ *errors.errorString \"disk full\"\n" +
+		"└─ *errors.errorString \"audit failed\"\n"
+	if got := buf.String(); got != expected {
+		t.Errorf("Dump(tree) =\n%s\nwant\n%s", got, expected)
+	}
+}
+
+func TestDump_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Dump(ProcessUserData(7), &buf, DumpJSON); err != nil {
+		t.Fatalf("Dump() error = %v", err)
+	}
+
+	var root dumpNode
+	if err := json.Unmarshal(buf.Bytes(), &root); err != nil {
+		t.Fatalf("Dump(json) is not valid JSON: %v", err)
+	}
+	var stacks, fields int
+	for n := []*dumpNode{&root}; len(n) > 0; n = append(n[1:], n[0].Causes...) {
+		if len(n[0].Stack) > 0 {
+			stacks++
+		}
+		if len(n[0].Fields) > 0 {
+			fields++
+		}
+	}
+	if stacks != 1 || fields != 2 {
+		t.Errorf("Dump(json) has %d stacks and %d field sets; want 1 and 2", stacks, fields)
+	}
+}
+
+func TestDump_DOT(t *testing.T) {
+	err := Here(errors.New(`say "hi"`))
+
+	var buf bytes.Buffer
+	if dumpErr := Dump(err, &buf, DumpDOT); dumpErr != nil {
+		t.Fatalf("Dump() error = %v", dumpErr)
+	}
+	got := buf.String()
+	if !strings.HasPrefix(got, "digraph errors {\n") || !strings.Contains(got, "\tn0 -> n1;\n") ||
+		!strings.Contains(got, `say \"hi\"`) || !strings.Contains(got, `\nat go-error-handling/wrapping.TestDump_DOT (`) {
+		t.Errorf("Dump(dot) = %q; want a digraph with escaped labels and an edge to the cause", got)
+	}
+}
+
+func TestDump_Errors(t *testing.T) {
+	var buf bytes.Buffer
+	if err := Dump(errors.New("x"), &buf, "yaml"); err == nil {
+		t.Error("Dump with an unknown format should fail")
+	}
+	if err := Dump(nil, &buf, DumpJSON); err != nil || buf.Len() != 0 {
+		t.Errorf("Dump(nil) = %v, wrote %q; want nothing", err, buf.String())
+	}
+
+	self := &errtest.LoopError{}
+	self.Next = self
+	buf.Reset()
+	if err := Dump(self, &buf, DumpTree); err != nil || !strings.Contains(buf.String(), "*wrapping.ErrChainTooDeep \"error chain wraps itself at depth 1\"") {
+		t.Errorf("Dump(cycle) = %v, wrote %q; want an ErrChainTooDeep node", err, buf.String())
+	}
+}
@@ -0,0 +1,74 @@
+package wrapping
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// badKey is the key given to a value whose key is missing or not a string,
+// matching log/slog.
+const badKey = "!BADKEY"
+
+// fieldsError annotates an error with structured fields without changing
+// its message.
+type fieldsError struct {
+	err    error
+	fields map[string]any
+}
+
+// WithFields returns err annotated with key/value pairs, given as
+// alternating keys and values like log/slog, so logs can record them as
+// fields instead of parsing the message. A value without a string key is
+// stored under "!BADKEY". It returns nil for nil.
+func WithFields(err error, kvs ...any) error {
+	if err == nil {
+		return nil
+	}
+	fields := make(map[string]any, (len(kvs)+1)/2)
+	for len(kvs) > 0 {
+		key, ok := kvs[0].(string)
+		if !ok || len(kvs) == 1 {
+			fields[badKey] = kvs[0]
+			kvs = kvs[1:]
+			continue
+		}
+		fields[key] = kvs[1]
+		kvs = kvs[2:]
+	}
+	return &fieldsError{err: err, fields: fields}
+}
+
+func (e *fieldsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldsError) Unwrap() error {
+	return e.err
+}
+
+// Format prints the message for %v and %s, and the chain as rendered by
+// Format, including the fields, for %+v.
+func (e *fieldsError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, e)
+}
+
+// Fields merges the fields of every WithFields wrapper in err's chain,
+// including joined errors. Where keys repeat, the outermost wrapper wins,
+// and among joined branches the earlier one.
+// It returns nil when there are none.
+func Fields(err error) map[string]any {
+	// Walk order is outermost first, so merge in reverse to let outer
+	// fields overwrite inner ones.
+	chain := slices.Collect(All(err))
+	var merged map[string]any
+	for i := len(chain) - 1; i >= 0; i-- {
+		if fe, ok := chain[i].(*fieldsError); ok {
+			if merged == nil {
+				merged = make(map[string]any)
+			}
+			maps.Copy(merged, fe.fields)
+		}
+	}
+	return merged
+}
@@ -0,0 +1,54 @@
+package wrapping
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFields_ProcessUserData(t *testing.T) {
+	err := ProcessUserData(42)
+
+	expected := map[string]any{"userID": 42, "filename": "user_42.json"}
+	if got := Fields(err); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Fields() = %v; want %v", got, expected)
+	}
+	if !strings.Contains(fmt.Sprintf("%+v", err), "wrapping.LoadConfig") {
+		t.Error("verbose formatting should still print the stack recorded beneath the fields")
+	}
+}
+
+func TestWithFields(t *testing.T) {
+	if WithFields(nil, "k", "v") != nil {
+		t.Error("WithFields(nil) should be nil")
+	}
+
+	inner := WithFields(os.ErrNotExist, "path", "/tmp/a", "attempt", 1)
+	outer := WithFields(fmt.Errorf("load: %w", inner), "attempt", 2, 7, "orphan")
+
+	if outer.Error() != "load: file does not exist" || !errors.Is(outer, os.ErrNotExist) {
+		t.Errorf("WithFields() = %v; want the message and chain unchanged", outer)
+	}
+	expected := map[string]any{"path": "/tmp/a", "attempt": 2, badKey: "orphan"}
+	if got := Fields(outer); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Fields() = %v; want %v", got, expected)
+	}
+}
+
+func TestFields_JoinedAndNone(t *testing.T) {
+	err := errors.Join(
+		WithFields(errors.New("a"), "shard", 1, "table", "users"),
+		WithFields(errors.New("b"), "shard", 2, "host", "db-2"),
+	)
+	expected := map[string]any{"shard": 1, "table": "users", "host": "db-2"}
+	if got := Fields(err); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Fields(joined) = %v; want %v", got, expected)
+	}
+
+	if got := Fields(errors.New("plain")); got != nil {
+		t.Errorf("Fields(plain) = %v; want nil", got)
+	}
+}
@@ -0,0 +1,185 @@
+package wrapping
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// FormatOptions selects the optional parts of Format's output.
+type FormatOptions struct {
+	// Stack includes the stack recorded by WithStack or Errorf.
+	Stack bool
+	// Fields includes the fields attached by WithFields.
+	Fields bool
+}
+
+// Format renders err's chain one level per line, each indented two spaces
+// deeper than the error that wraps it. A level shows only the text its
+// error adds in front of its cause, so
+//
+//	failed to process user 1: failed to load config: open user_1.json: no such file
+//
+// becomes
+//
+//	failed to process user 1
+//	  failed to load config
+//	    open user_1.json: no such file
+//
+// Annotations from Here, WithFields and WithStack are shown with the level
+// they wrap: fields as {key=value ...} after its text, then the location
+// from Here and the stack on indented lines below. Joined errors are shown
+// as "N errors" with each error indented beneath. A Redact wrapper ends its
+// branch, and a chain Walk cannot finish ends with the *ErrChainTooDeep's
+// message. It returns "" for nil.
+func Format(err error, opts FormatOptions) string {
+	var b strings.Builder
+	// levels[d] holds the indent and pending annotations for errors at
+	// depth d of the tree, as set by their parent.
+	levels := []level{{}}
+	walkErr := walkRendered(err, func(e error, depth int) bool {
+		current := levels[depth]
+		next := level{indent: current.indent + 1}
+		if text, ok := levelText(e); ok {
+			writeLevel(&b, text, current.indent, opts, current.ann)
+		} else {
+			next = level{indent: current.indent, ann: current.ann.add(e)}
+		}
+		levels = append(levels[:depth+1], next)
+		return true
+	})
+	if tooDeep, ok := walkErr.(*ErrChainTooDeep); ok {
+		writeLevel(&b, tooDeep.Error(), levels[tooDeep.Depth].indent, opts, annotations{})
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+type level struct {
+	indent int
+	ann    annotations
+}
+
+// annotations collects what the wrappers above a level recorded about it.
+type annotations struct {
+	fields map[string]any
+	pc     uintptr
+	stack  []uintptr
+}
+
+// add returns a with e's annotations added, keeping the outermost value of
+// each to match Fields and Location.
+func (a annotations) add(e error) annotations {
+	switch x := e.(type) {
+	case *fieldsError:
+		merged := maps.Clone(x.fields)
+		maps.Copy(merged, a.fields)
+		a.fields = merged
+	case *locationError:
+		if a.pc == 0 {
+			a.pc = x.pc
+		}
+	case *stackError:
+		if a.stack == nil {
+			a.stack = x.stack
+		}
+	}
+	return a
+}
+
+// levelText returns the text e adds to the chain, or false if e only
+// annotates or decorates its cause and shares its level.
+func levelText(e error) (string, bool) {
+	switch x := e.(type) {
+	case *fieldsError, *locationError, *stackError:
+		return "", false
+	case *redactedError:
+		return e.Error(), true
+	case interface{ Unwrap() []error }:
+		children := x.Unwrap()
+		if text := ownText(e, joinedMessages(children)); text != "" {
+			return text, true
+		}
+		return fmt.Sprintf("%d errors", len(children)), true
+	case interface{ Unwrap() error }:
+		child := x.Unwrap()
+		if child == nil {
+			return e.Error(), true
+		}
+		text := ownText(e, child.Error())
+		return text, text != ""
+	}
+	return e.Error(), true
+}
+
+// ownText returns the part of err's message in front of its cause's
+// message, or the whole message if it does not end with the cause's.
+func ownText(err error, causeMsg string) string {
+	msg := err.Error()
+	if !strings.HasSuffix(msg, causeMsg) {
+		return msg
+	}
+	return strings.TrimRight(strings.TrimSuffix(msg, causeMsg), ": ")
+}
+
+// joinedMessages returns the message errors.Join would give children.
+func joinedMessages(children []error) string {
+	msgs := make([]string, 0, len(children))
+	for _, child := range children {
+		if child != nil {
+			msgs = append(msgs, child.Error())
+		}
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func writeLevel(w io.Writer, text string, depth int, opts FormatOptions, ann annotations) {
+	indent := strings.Repeat("  ", depth)
+	io.WriteString(w, indent+strings.ReplaceAll(text, "\n", "\n"+indent))
+	if opts.Fields && len(ann.fields) > 0 {
+		io.WriteString(w, " "+formatFields(ann.fields))
+	}
+	io.WriteString(w, "\n")
+	if ann.pc != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{ann.pc}).Next()
+		fmt.Fprintf(w, "%s  at %s (%s:%d)\n", indent, frame.Function, frame.File, frame.Line)
+	}
+	if opts.Stack && ann.stack != nil {
+		frames := runtime.CallersFrames(ann.stack)
+		for {
+			frame, more := frames.Next()
+			fmt.Fprintf(w, "%s  %s\n%s  \t%s:%d\n", indent, frame.Function, indent, frame.File, frame.Line)
+			if !more {
+				break
+			}
+		}
+	}
+}
+
+// formatFields renders fields as {key=value ...} sorted by key.
+func formatFields(fields map[string]any) string {
+	pairs := make([]string, 0, len(fields))
+	for _, k := range slices.Sorted(maps.Keys(fields)) {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", k, fields[k]))
+	}
+	return "{" + strings.Join(pairs, " ") + "}"
+}
+
+// formatError implements fmt.Formatter for this package's wrappers: the
+// message for %v and %s, and Format with stacks and fields for %+v.
+func formatError(s fmt.State, verb rune, err error) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			io.WriteString(s, Format(err, FormatOptions{Stack: true, Fields: true}))
+			return
+		}
+		io.WriteString(s, err.Error())
+	case 's':
+		io.WriteString(s, err.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", err.Error())
+	}
+}
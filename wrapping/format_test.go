@@ -0,0 +1,50 @@
+package wrapping
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFormat_Levels(t *testing.T) {
+	err := fmt.Errorf("failed to process user 7: %w",
+		WithFields(fmt.Errorf("failed to load config: %w", errors.New("no such file")), "userID", 7))
+
+	expected := "failed to process user 7\n" +
+		"  failed to load config {userID=7}\n" +
+		"    no such file"
+	if got := Format(err, FormatOptions{Fields: true}); got != expected {
+		t.Errorf("Format() = %q; want %q", got, expected)
+	}
+	if got := Format(err, FormatOptions{}); strings.Contains(got, "userID") {
+		t.Errorf("Format() without Fields = %q; want no fields", got)
+	}
+	if got := Format(nil, FormatOptions{}); got != "" {
+		t.Errorf("Format(nil) = %q; want empty", got)
+	}
+}
+
+func TestFormat_Joined(t *testing.T) {
+	err := fmt.Errorf("save: %w", errors.Join(errors.New("disk full"), errors.New("audit failed")))
+
+	expected := "save\n" +
+		"  2 errors\n" +
+		"    disk full\n" +
+		"    audit failed"
+	if got := Format(err, FormatOptions{}); got != expected {
+		t.Errorf("Format() = %q; want %q", got, expected)
+	}
+}
+
+func TestFormat_Stack(t *testing.T) {
+	err := ProcessUserData(7)
+
+	if got := Format(err, FormatOptions{}); strings.Contains(got, "\t") {
+		t.Errorf("Format() without Stack = %q; want no frames", got)
+	}
+	got := Format(err, FormatOptions{Stack: true})
+	if !strings.Contains(got, "\n      go-error-handling/wrapping.LoadConfig\n") {
+		t.Errorf("Format() with Stack = %q; want frames indented under their level", got)
+	}
+}
@@ -0,0 +1,29 @@
+package wrapping
+
+// lightError is the wrapper Light returns. Its message is only built when
+// Error is called.
+type lightError struct {
+	msg string
+	err error
+}
+
+// Light wraps err as "staticMsg: err" like fmt.Errorf("staticMsg: %w", err),
+// but without formatting: wrapping costs one small allocation for the
+// wrapper and the message is only concatenated if Error is called. Use it
+// where errors are wrapped per request and often dropped or matched with
+// errors.Is without being printed. staticMsg should be a constant; use
+// Errorf or fmt.Errorf for messages with values. It returns nil for nil.
+func Light(err error, staticMsg string) error {
+	if err == nil {
+		return nil
+	}
+	return &lightError{msg: staticMsg, err: err}
+}
+
+func (e *lightError) Error() string {
+	return e.msg + ": " + e.err.Error()
+}
+
+func (e *lightError) Unwrap() error {
+	return e.err
+}
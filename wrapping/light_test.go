@@ -0,0 +1,44 @@
+package wrapping
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestLight(t *testing.T) {
+	if Light(nil, "query users") != nil {
+		t.Error("Light(nil) should be nil")
+	}
+
+	err := Light(os.ErrDeadlineExceeded, "query users")
+	expected := fmt.Errorf("query users: %w", os.ErrDeadlineExceeded)
+	if err.Error() != expected.Error() || !errors.Is(err, os.ErrDeadlineExceeded) {
+		t.Errorf("Light() = %v; want %v with the chain kept", err, expected)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		_ = Light(os.ErrDeadlineExceeded, "query users")
+	})
+	if allocs > 1 {
+		t.Errorf("Light allocates %.0f times; want at most 1", allocs)
+	}
+}
+
+var sinkErr error
+
+func BenchmarkWrap(b *testing.B) {
+	b.Run("Light", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkErr = Light(os.ErrDeadlineExceeded, "query users")
+		}
+	})
+	b.Run("fmt.Errorf", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			sinkErr = fmt.Errorf("query users: %w", os.ErrDeadlineExceeded)
+		}
+	})
+}
@@ -0,0 +1,50 @@
+package wrapping
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+)
+
+// locationError annotates an error with a single frame: the place Here was
+// called. It is a cheaper alternative to a full stack.
+type locationError struct {
+	err error
+	pc  uintptr
+}
+
+// Here returns err annotated with the file, line and function of its
+// caller. Printing the result with %+v includes that location; see Format. It returns
+// nil for nil.
+func Here(err error) error {
+	if err == nil {
+		return nil
+	}
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	return &locationError{err: err, pc: pcs[0]}
+}
+
+func (e *locationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *locationError) Unwrap() error {
+	return e.err
+}
+
+// Format prints the message for %v and %s, and the chain as rendered by
+// Format for %+v.
+func (e *locationError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, e)
+}
+
+// Location returns the frame recorded by the outermost Here in err's chain.
+func Location(err error) (runtime.Frame, bool) {
+	var le *locationError
+	if !errors.As(err, &le) {
+		return runtime.Frame{}, false
+	}
+	frame, _ := runtime.CallersFrames([]uintptr{le.pc}).Next()
+	return frame, true
+}
@@ -0,0 +1,44 @@
+package wrapping
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHere(t *testing.T) {
+	if Here(nil) != nil {
+		t.Error("Here(nil) should be nil")
+	}
+
+	err := Here(os.ErrNotExist)
+	if !errors.Is(err, os.ErrNotExist) || err.Error() != os.ErrNotExist.Error() {
+		t.Errorf("Here() = %v; want the original error and message", err)
+	}
+
+	frame, ok := Location(fmt.Errorf("load: %w", err))
+	if !ok || !strings.HasSuffix(frame.Function, "TestHere") || !strings.HasSuffix(frame.File, "location_test.go") {
+		t.Errorf("Location() = %s (%s:%d), %t; want the caller of Here", frame.Function, frame.File, frame.Line, ok)
+	}
+	if _, ok := Location(errors.New("plain")); ok {
+		t.Error("Location of an error without Here should report false")
+	}
+}
+
+func TestLocationError_Format(t *testing.T) {
+	err := Here(errors.New("boom"))
+
+	if got := fmt.Sprintf("%v", err); got != "boom" {
+		t.Errorf("%%v = %q; want the message only", got)
+	}
+	if got := fmt.Sprintf("%+v", err); !strings.HasPrefix(got, "boom\n  at ") || !strings.Contains(got, "TestLocationError_Format (") {
+		t.Errorf("%%+v = %q; want the message followed by the location", got)
+	}
+
+	withStack := WithStack(Here(errors.New("boom")))
+	if got := fmt.Sprintf("%+v", withStack); !strings.Contains(got, "\n  at ") || !strings.Contains(got, "\t") {
+		t.Errorf("%%+v = %q; want both the location and the stack", got)
+	}
+}
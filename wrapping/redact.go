@@ -0,0 +1,56 @@
+package wrapping
+
+import "regexp"
+
+// RedactRule replaces every match of Pattern in an error message with
+// Replacement, which may refer to submatches as in
+// regexp.Regexp.ReplaceAllString.
+type RedactRule struct {
+	Pattern     *regexp.Regexp
+	Replacement string
+}
+
+// DefaultRedactRules mask, in order, email addresses, absolute Unix and
+// Windows paths, and hostnames or IPv4 addresses. To avoid masking file
+// names such as "config.json", only hostnames with at least three labels
+// ("db.internal.example") are recognised.
+var DefaultRedactRules = []RedactRule{
+	{regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`), "[email]"},
+	{regexp.MustCompile(`(^|[\s"'(=])(?:/|[A-Za-z]:\\)[^\s"',:]+`), "${1}[path]"},
+	{regexp.MustCompile(`\b(?:[A-Za-z0-9](?:[A-Za-z0-9-]*[A-Za-z0-9])?\.){2,}[A-Za-z]{2,}\b|\b\d{1,3}(?:\.\d{1,3}){3}\b`), "[host]"},
+}
+
+// redactedError shows a masked message while keeping the original chain.
+type redactedError struct {
+	err error
+	msg string
+}
+
+// Redact returns err with its message masked by rules, applied in order,
+// or by DefaultRedactRules if none are given, so it can be returned to
+// clients. errors.Is and errors.As still reach the original error, but
+// nothing that prints it does: the result has no fmt.Formatter, so %+v
+// prints only the masked message, and Format, Dump and the %+v of this
+// package's wrappers show it as the last error in the chain, without the
+// causes, fields or stacks beneath it. It returns nil for nil.
+func Redact(err error, rules ...RedactRule) error {
+	if err == nil {
+		return nil
+	}
+	if len(rules) == 0 {
+		rules = DefaultRedactRules
+	}
+	msg := err.Error()
+	for _, rule := range rules {
+		msg = rule.Pattern.ReplaceAllString(msg, rule.Replacement)
+	}
+	return &redactedError{err: err, msg: msg}
+}
+
+func (e *redactedError) Error() string {
+	return e.msg
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
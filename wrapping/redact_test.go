@@ -0,0 +1,88 @@
+package wrapping
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedact_DefaultRules(t *testing.T) {
+	tests := []struct {
+		msg      string
+		expected string
+	}{
+		{"open /etc/app/secrets.yaml: permission denied", "open [path]: permission denied"},
+		{`read "C:\Users\ann\config.json" failed`, `read "[path]" failed`},
+		{"no account for ann.lee+work@example.co.uk", "no account for [email]"},
+		{"dial tcp db-1.prod.example.com:5432: i/o timeout", "dial tcp [host]:5432: i/o timeout"},
+		{"connect to 10.0.3.17 refused", "connect to [host] refused"},
+		{"failed to read config file user_7.json", "failed to read config file user_7.json"},
+	}
+	for _, tt := range tests {
+		if got := Redact(errors.New(tt.msg)).Error(); got != tt.expected {
+			t.Errorf("Redact(%q) = %q; want %q", tt.msg, got, tt.expected)
+		}
+	}
+}
+
+func TestRedact_KeepsChain(t *testing.T) {
+	_, readErr := os.ReadFile("/nonexistent/dir/user.json")
+	err := Redact(WithStack(fmt.Errorf("load: %w", readErr)))
+
+	if got, expected := err.Error(), "load: open [path]: no such file or directory"; got != expected {
+		t.Errorf("Redact() = %q; want %q", got, expected)
+	}
+	if got := fmt.Sprintf("%+v", err); got != err.Error() {
+		t.Errorf("%%+v = %q; want only the masked message", got)
+	}
+
+	var pathErr *fs.PathError
+	if !errors.Is(err, os.ErrNotExist) || !errors.As(err, &pathErr) || pathErr.Path != "/nonexistent/dir/user.json" {
+		t.Error("Redact should keep the original error reachable through errors.Is and errors.As")
+	}
+	if Redact(nil) != nil {
+		t.Error("Redact(nil) should be nil")
+	}
+}
+
+func TestRedact_CustomRules(t *testing.T) {
+	token := RedactRule{regexp.MustCompile(`token=\w+`), "token=***"}
+
+	got := Redact(errors.New("bad token=abc123 from ann@example.com"), token).Error()
+	if expected := "bad token=*** from ann@example.com"; got != expected {
+		t.Errorf("Redact() = %q; want %q, with only the given rules applied", got, expected)
+	}
+}
+
+func TestRedact_OpaqueToFormatters(t *testing.T) {
+	secret := WithFields(errors.New("open /home/alice/secret.json: denied for bob@example.com"), "owner", "bob@example.com")
+	err := Redact(secret)
+
+	var dump bytes.Buffer
+	if dumpErr := Dump(err, &dump, DumpTree); dumpErr != nil {
+		t.Fatalf("Dump() error = %v", dumpErr)
+	}
+	outputs := map[string]string{
+		"%+v through WithStack": fmt.Sprintf("%+v", WithStack(err)),
+		"%+v through Here":      fmt.Sprintf("%+v", Here(fmt.Errorf("handler: %w", err))),
+		"Format":                Format(err, FormatOptions{Stack: true, Fields: true}),
+		"Dump":                  dump.String(),
+	}
+	for name, got := range outputs {
+		if strings.Contains(got, "alice") || strings.Contains(got, "bob@") {
+			t.Errorf("%s = %q; want the unmasked error hidden", name, got)
+		}
+		if !strings.Contains(got, "open [path]: denied for [email]") {
+			t.Errorf("%s = %q; want the masked message", name, got)
+		}
+	}
+
+	if !errors.Is(err, secret) {
+		t.Error("errors.Is should still reach the error beneath Redact")
+	}
+}
@@ -0,0 +1,86 @@
+package wrapping
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// maxStackDepth bounds how many frames WithStack and Errorf record.
+const maxStackDepth = 32
+
+// stackError annotates an error with the call stack of the first wrap in
+// its chain. Later wraps keep stack nil so the stack is captured only once,
+// but still print it under %+v.
+type stackError struct {
+	err   error
+	stack []uintptr
+}
+
+// WithStack returns err annotated with the caller's stack, unless a stack
+// was already recorded further down the chain. Printing the result with
+// %+v includes the stack; see Format. It returns nil for nil.
+func WithStack(err error) error {
+	return wrapStack(err)
+}
+
+// Errorf is fmt.Errorf that records the caller's stack like WithStack.
+func Errorf(format string, args ...any) error {
+	return wrapStack(fmt.Errorf(format, args...))
+}
+
+// wrapStack must be called directly by the exported wrappers so the
+// recorded stack starts at their caller.
+func wrapStack(err error) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := &stackError{err: err}
+	if findStack(err) == nil {
+		pcs := make([]uintptr, maxStackDepth)
+		wrapped.stack = pcs[:runtime.Callers(3, pcs)]
+	}
+	return wrapped
+}
+
+func (e *stackError) Error() string {
+	return e.err.Error()
+}
+
+func (e *stackError) Unwrap() error {
+	return e.err
+}
+
+// Format prints the message for %v and %s, and the chain as rendered by
+// Format, including the recorded stack, for %+v.
+func (e *stackError) Format(s fmt.State, verb rune) {
+	formatError(s, verb, e)
+}
+
+// StackTrace returns the frames recorded by WithStack or Errorf anywhere in
+// err's chain, or nil if there are none.
+func StackTrace(err error) []runtime.Frame {
+	pcs := findStack(err)
+	if pcs == nil {
+		return nil
+	}
+	var trace []runtime.Frame
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		trace = append(trace, frame)
+		if !more {
+			return trace
+		}
+	}
+}
+
+// findStack returns the first recorded stack in err's chain, searching
+// joined errors depth-first.
+func findStack(err error) []uintptr {
+	for current := range All(err) {
+		if se, ok := current.(*stackError); ok && se.stack != nil {
+			return se.stack
+		}
+	}
+	return nil
+}
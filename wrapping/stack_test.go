@@ -0,0 +1,72 @@
+package wrapping
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestErrorf_RecordsStackOnce(t *testing.T) {
+	err := ProcessUserData(999)
+
+	trace := StackTrace(err)
+	if len(trace) == 0 {
+		t.Fatal("StackTrace(ProcessUserData) is empty; want the frames of the failed read")
+	}
+	if !strings.HasSuffix(trace[0].Function, "wrapping.LoadConfig") {
+		t.Errorf("StackTrace()[0] = %s; want LoadConfig, where the stack was first recorded", trace[0].Function)
+	}
+
+	recorded := 0
+	for current := range All(err) {
+		if se, ok := current.(*stackError); ok && se.stack != nil {
+			recorded++
+		}
+	}
+	if recorded != 1 {
+		t.Errorf("chain records %d stacks; want exactly 1", recorded)
+	}
+}
+
+func TestWithStack(t *testing.T) {
+	if WithStack(nil) != nil {
+		t.Error("WithStack(nil) should be nil")
+	}
+
+	err := WithStack(os.ErrNotExist)
+	if !errors.Is(err, os.ErrNotExist) || err.Error() != os.ErrNotExist.Error() {
+		t.Errorf("WithStack() = %v; want the original error and message", err)
+	}
+	if trace := StackTrace(err); len(trace) == 0 || !strings.HasSuffix(trace[0].Function, "TestWithStack") {
+		t.Errorf("StackTrace() = %v; want it to start at the caller", trace)
+	}
+	if StackTrace(errors.New("plain")) != nil {
+		t.Error("StackTrace of an error without a stack should be nil")
+	}
+}
+
+func TestStackTrace_JoinedErrors(t *testing.T) {
+	err := errors.Join(errors.New("audit failed"), fmt.Errorf("save: %w", WithStack(os.ErrPermission)))
+	if len(StackTrace(err)) == 0 {
+		t.Error("StackTrace should find a stack inside a joined error")
+	}
+}
+
+func TestStackError_Format(t *testing.T) {
+	err := ProcessUserData(999)
+
+	if got := fmt.Sprintf("%v", err); got != err.Error() {
+		t.Errorf("%%v = %q; want the message only", got)
+	}
+	if got := fmt.Sprintf("%s", err); got != err.Error() {
+		t.Errorf("%%s = %q; want the message only", got)
+	}
+
+	verbose := fmt.Sprintf("%+v", err)
+	if !strings.Contains(verbose, "failed to read config file user_999.json {filename=user_999.json}\n      go-error-handling/wrapping.LoadConfig\n      \t") ||
+		!strings.Contains(verbose, "wrapping_error.go:") {
+		t.Errorf("%%+v = %q; want the stack beneath the level that recorded it", verbose)
+	}
+}
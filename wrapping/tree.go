@@ -0,0 +1,59 @@
+package wrapping
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Flatten returns every error in err's tree in the order Walk visits them:
+// err first, then each cause depth-first, with joined branches left to
+// right. It returns nil for nil.
+func Flatten(err error) []error {
+	return slices.Collect(All(err))
+}
+
+// Leaves returns the errors in err's tree that wrap nothing, left to right.
+// For a linear chain that is the single root cause; for errors.Join it is
+// the root cause of every branch.
+func Leaves(err error) []error {
+	var leaves []error
+	Walk(err, func(e error) bool {
+		switch x := e.(type) {
+		case interface{ Unwrap() error }:
+			if x.Unwrap() != nil {
+				return true
+			}
+		case interface{ Unwrap() []error }:
+			if slices.ContainsFunc(x.Unwrap(), func(child error) bool { return child != nil }) {
+				return true
+			}
+		}
+		leaves = append(leaves, e)
+		return true
+	})
+	return leaves
+}
+
+// CountMatching reports how many errors in err's tree match target by
+// themselves, using errors.Is's test for a single error: equality, or an
+// Is method that reports true. Wrappers are not counted just because a
+// cause beneath them matches, so
+//
+//	CountMatching(errors.Join(ErrA, fmt.Errorf("x: %w", ErrA)), ErrA)
+//
+// is 2.
+func CountMatching(err, target error) int {
+	if target == nil {
+		return 0
+	}
+	isComparable := reflect.TypeOf(target).Comparable()
+	count := 0
+	for e := range All(err) {
+		if isComparable && e == target {
+			count++
+		} else if x, ok := e.(interface{ Is(error) bool }); ok && x.Is(target) {
+			count++
+		}
+	}
+	return count
+}
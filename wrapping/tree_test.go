@@ -0,0 +1,59 @@
+package wrapping
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"testing"
+)
+
+// multiError is a custom multi-unwrap type, not built with errors.Join.
+type multiError []error
+
+func (m multiError) Error() string   { return fmt.Sprintf("%d errors", len(m)) }
+func (m multiError) Unwrap() []error { return m }
+
+func TestFlattenAndLeaves(t *testing.T) {
+	wrapped := fmt.Errorf("read: %w", io.ErrUnexpectedEOF)
+	err := errors.Join(wrapped, multiError{os.ErrNotExist, nil, os.ErrPermission})
+
+	flat := Flatten(err)
+	if len(flat) != 6 || flat[0] != err || flat[1] != wrapped || flat[2] != io.ErrUnexpectedEOF {
+		t.Errorf("Flatten() = %v; want the tree depth-first from the root", flat)
+	}
+
+	leaves := Leaves(err)
+	expected := []error{io.ErrUnexpectedEOF, os.ErrNotExist, os.ErrPermission}
+	if !slices.Equal(leaves, expected) {
+		t.Errorf("Leaves() = %v; want %v", leaves, expected)
+	}
+
+	if Flatten(nil) != nil || Leaves(nil) != nil {
+		t.Error("Flatten(nil) and Leaves(nil) should be nil")
+	}
+}
+
+func TestCountMatching(t *testing.T) {
+	err := errors.Join(
+		os.ErrNotExist,
+		fmt.Errorf("stat: %w", os.ErrNotExist),
+		multiError{os.ErrNotExist, os.ErrPermission},
+	)
+
+	tests := []struct {
+		target   error
+		expected int
+	}{
+		{os.ErrNotExist, 3},
+		{os.ErrPermission, 1},
+		{io.EOF, 0},
+		{nil, 0},
+	}
+	for _, tt := range tests {
+		if got := CountMatching(err, tt.target); got != tt.expected {
+			t.Errorf("CountMatching(%v) = %d; want %d", tt.target, got, tt.expected)
+		}
+	}
+}
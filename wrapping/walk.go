@@ -0,0 +1,112 @@
+package wrapping
+
+import (
+	"fmt"
+	"iter"
+	"reflect"
+)
+
+// MaxChainDepth bounds how many levels deep Walk, All and the helpers built
+// on them descend before giving up with an *ErrChainTooDeep.
+var MaxChainDepth = 1000
+
+// ErrChainTooDeep is returned by Walk when a chain nests deeper than
+// MaxChainDepth, or wraps itself so that traversal would never end.
+type ErrChainTooDeep struct {
+	// Depth is the level, counting err itself as 0, where traversal stopped.
+	Depth int
+	// Cycle reports that the error at Depth already appeared above it.
+	Cycle bool
+}
+
+func (e *ErrChainTooDeep) Error() string {
+	if e.Cycle {
+		return fmt.Sprintf("error chain wraps itself at depth %d", e.Depth)
+	}
+	return fmt.Sprintf("error chain deeper than %d levels", e.Depth)
+}
+
+// Walk calls fn for err and every error beneath it, depth-first, visiting
+// the children of errors implementing Unwrap() []error (such as
+// errors.Join) left to right. This is the order errors.Is and errors.As
+// search in. Walk stops as soon as fn returns false.
+//
+// Walk also stops, returning an *ErrChainTooDeep, at an error nested
+// deeper than MaxChainDepth or one that is its own cause, as detected by
+// pointer identity. Otherwise it returns nil.
+func Walk(err error, fn func(error) bool) error {
+	return walkDepth(err, func(e error, _ int) bool { return fn(e) })
+}
+
+// All returns an iterator over err and every error beneath it, in the
+// order Walk visits them. It ends early, without reporting why, where Walk
+// would return an *ErrChainTooDeep.
+func All(err error) iter.Seq[error] {
+	return func(yield func(error) bool) {
+		Walk(err, yield)
+	}
+}
+
+// walkDepth is Walk that also passes fn each error's depth.
+func walkDepth(err error, fn func(e error, depth int) bool) error {
+	w := walker{fn: fn}
+	w.walk(err, 0)
+	return w.err
+}
+
+// walkRendered is walkDepth for output that may reach clients: a Redact
+// wrapper is visited but treated as a leaf, so the unmasked errors
+// beneath it are never shown.
+func walkRendered(err error, fn func(e error, depth int) bool) error {
+	w := walker{fn: fn, stopAtRedacted: true}
+	w.walk(err, 0)
+	return w.err
+}
+
+type walker struct {
+	fn             func(error, int) bool
+	stopAtRedacted bool
+	// path holds the pointer errors above the one being visited.
+	path map[error]struct{}
+	err  error
+}
+
+// walk reports whether the traversal should continue.
+func (w *walker) walk(err error, depth int) bool {
+	if err == nil {
+		return true
+	}
+	if depth >= MaxChainDepth {
+		w.err = &ErrChainTooDeep{Depth: depth}
+		return false
+	}
+	if reflect.TypeOf(err).Kind() == reflect.Pointer {
+		if _, seen := w.path[err]; seen {
+			w.err = &ErrChainTooDeep{Depth: depth, Cycle: true}
+			return false
+		}
+		if w.path == nil {
+			w.path = make(map[error]struct{})
+		}
+		w.path[err] = struct{}{}
+		defer delete(w.path, err)
+	}
+
+	if !w.fn(err, depth) {
+		return false
+	}
+	if _, ok := err.(*redactedError); ok && w.stopAtRedacted {
+		return true
+	}
+	switch x := err.(type) {
+	case interface{ Unwrap() error }:
+		return w.walk(x.Unwrap(), depth+1)
+	case interface{ Unwrap() []error }:
+		for _, child := range x.Unwrap() {
+			if !w.walk(child, depth+1) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,106 @@
+package wrapping
+
+import (
+	"errors"
+	"fmt"
+	"go-error-handling/internal/errtest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAll_Order(t *testing.T) {
+	a, b, c := errors.New("a"), errors.New("b"), errors.New("c")
+	joined := errors.Join(fmt.Errorf("wrap a: %w", a), b)
+	err := fmt.Errorf("outer: %w", errors.Join(joined, c))
+
+	var got []string
+	for current := range All(err) {
+		got = append(got, current.Error())
+	}
+	expected := []string{
+		err.Error(),
+		errors.Join(joined, c).Error(),
+		joined.Error(),
+		"wrap a: a",
+		"a",
+		"b",
+		"c",
+	}
+	if !slices.Equal(got, expected) {
+		t.Errorf("All() = %q; want %q", got, expected)
+	}
+
+	if n := len(slices.Collect(All(nil))); n != 0 {
+		t.Errorf("All(nil) yielded %d errors; want 0", n)
+	}
+}
+
+func TestWalk_Stops(t *testing.T) {
+	target := errors.New("target")
+	err := errors.Join(errors.New("first"), fmt.Errorf("second: %w", target), errors.New("third"))
+
+	var visited []error
+	Walk(err, func(e error) bool {
+		visited = append(visited, e)
+		return e != target
+	})
+	if len(visited) != 4 || visited[len(visited)-1] != target {
+		t.Errorf("Walk visited %v; want it to stop at the target after 4 errors", visited)
+	}
+
+	// Breaking out of a range loop must stop the iterator too.
+	count := 0
+	for range All(err) {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Errorf("range over All ran %d times after break; want 1", count)
+	}
+}
+
+func TestWalk_Cycle(t *testing.T) {
+	self := &errtest.LoopError{}
+	self.Next = fmt.Errorf("again: %w", self)
+
+	visited := 0
+	err := Walk(fmt.Errorf("outer: %w", self), func(error) bool {
+		visited++
+		return true
+	})
+
+	var tooDeep *ErrChainTooDeep
+	if !errors.As(err, &tooDeep) || !tooDeep.Cycle || tooDeep.Depth != 3 {
+		t.Fatalf("Walk() = %v; want a cycle reported at depth 3", err)
+	}
+	if visited != 3 {
+		t.Errorf("Walk visited %d errors; want 3 before the cycle", visited)
+	}
+	if got := Format(self, FormatOptions{}); !strings.HasSuffix(got, "error chain wraps itself at depth 2") {
+		t.Errorf("Format() = %q; want it to end with the cycle", got)
+	}
+}
+
+func TestWalk_MaxChainDepth(t *testing.T) {
+	defer func(old int) { MaxChainDepth = old }(MaxChainDepth)
+	MaxChainDepth = 5
+
+	err := errors.New("root")
+	for i := range 10 {
+		err = fmt.Errorf("level %d: %w", i, err)
+	}
+
+	var tooDeep *ErrChainTooDeep
+	if walkErr := Walk(err, func(error) bool { return true }); !errors.As(walkErr, &tooDeep) || tooDeep.Cycle || tooDeep.Depth != 5 {
+		t.Errorf("Walk() = %v; want the depth limit reported at 5", walkErr)
+	}
+	if n := len(Flatten(err)); n != 5 {
+		t.Errorf("Flatten() returned %d errors; want 5 within the limit", n)
+	}
+
+	shared := errors.New("shared")
+	if walkErr := Walk(errors.Join(shared, shared), func(error) bool { return true }); walkErr != nil {
+		t.Errorf("Walk() = %v; an error repeated in sibling branches is not a cycle", walkErr)
+	}
+}
@@ -5,27 +5,23 @@ import (
 	"os"
 )
 
+// ProcessUserData loads a user's config. Each layer wraps with Errorf, so
+// the stack is captured once, where loading the file failed, and printing
+// the error with %+v shows it. The user ID and filename are also attached
+// as fields for structured logs; see Fields.
 func ProcessUserData(userID int) error {
 	err := loadUserConfig(userID)
 	if err != nil {
-		return fmt.Errorf("failed to process user %d: %w", userID, err)
+		return Errorf("failed to process user %d: %w", userID, WithFields(err, "userID", userID))
 	}
 	return nil
 }
 
 func loadUserConfig(userID int) error {
 	filename := fmt.Sprintf("user_%d.json", userID)
-	err := readConfigFile(filename)
+	_, err := LoadConfig(os.DirFS("."), filename)
 	if err != nil {
-		return fmt.Errorf("failed to load config for user %d: %w", userID, err)
-	}
-	return nil
-}
-
-func readConfigFile(filename string) error {
-	_, err := os.ReadFile(filename)
-	if err != nil {
-		return fmt.Errorf("failed to read config file %s: %w", filename, err)
+		return Errorf("failed to load config for user %d: %w", userID, err)
 	}
 	return nil
 }
@@ -1,11 +1,13 @@
 package wrapping
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 func TestProcessUserData_FileNotFound(t *testing.T) {
@@ -52,22 +54,9 @@ func TestProcessUserData_ErrorChain(t *testing.T) {
 			}
 
 			// Test the error chain depth
-			var currentErr error = err
 			depth := 0
-
-			for currentErr != nil {
+			for range All(err) {
 				depth++
-				if depth > 10 { // Prevent infinite loop
-					t.Error("Error chain too deep, possible infinite loop")
-					break
-				}
-
-				// Try to unwrap
-				if unwrapper, ok := currentErr.(interface{ Unwrap() error }); ok {
-					currentErr = unwrapper.Unwrap()
-				} else {
-					break
-				}
 			}
 
 			// Should have multiple levels in the error chain
@@ -107,40 +96,30 @@ func TestLoadUserConfig_ErrorPropagation(t *testing.T) {
 	}
 }
 
-func TestReadConfigFile_Success(t *testing.T) {
-	// Create a temporary file for testing
-	tmpFile, err := os.CreateTemp("", "test_config_*.json")
-	if err != nil {
-		t.Fatalf("Failed to create temp file: %v", err)
-	}
-	defer os.Remove(tmpFile.Name())
-	defer tmpFile.Close()
-
-	// Write some test data
-	testData := `{"name": "test", "value": 123}`
-	if _, err := tmpFile.WriteString(testData); err != nil {
-		t.Fatalf("Failed to write test data: %v", err)
-	}
+func TestLoadConfig_Success(t *testing.T) {
+	fsys := fstest.MapFS{"test_config.json": {Data: []byte(`{"name": "test", "value": 123}`)}}
 
-	// Test reading the file
-	err = readConfigFile(tmpFile.Name())
+	cfg, err := LoadConfig(fsys, "test_config.json")
 	if err != nil {
-		t.Errorf("readConfigFile with valid file should not return error, got: %v", err)
+		t.Fatalf("LoadConfig with valid file should not return error, got: %v", err)
+	}
+	if cfg.Name != "test" || cfg.Value != 123 {
+		t.Errorf("LoadConfig() = %+v; want {Name:test Value:123}", *cfg)
 	}
 }
 
-func TestReadConfigFile_FileNotFound(t *testing.T) {
+func TestLoadConfig_FileNotFound(t *testing.T) {
 	nonExistentFile := "definitely_does_not_exist_12345.json"
 
-	err := readConfigFile(nonExistentFile)
+	_, err := LoadConfig(fstest.MapFS{}, nonExistentFile)
 
 	if err == nil {
-		t.Error("readConfigFile with non-existent file should return error")
+		t.Fatal("LoadConfig with non-existent file should return error")
 	}
 
 	// Check that it wraps os.ErrNotExist
 	if !errors.Is(err, os.ErrNotExist) {
-		t.Error("readConfigFile error should wrap os.ErrNotExist")
+		t.Error("LoadConfig error should wrap os.ErrNotExist")
 	}
 
 	// Check error message format
@@ -157,22 +136,52 @@ func TestReadConfigFile_FileNotFound(t *testing.T) {
 	}
 }
 
+func TestLoadConfig_ParseErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		line     int
+		column   int
+		key      string
+		expected any
+	}{
+		{"syntax", "{\n  \"name\": \"test\",\n  \"value\": 12x\n}", 3, 14, "", new(*json.SyntaxError)},
+		{"truncated", "{\"name\": \"test\"", 1, 15, "", new(*json.SyntaxError)},
+		{"type", "{\n  \"name\": \"test\",\n  \"value\": \"high\"\n}", 3, 17, "value", new(*json.UnmarshalTypeError)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{"user.json": {Data: []byte(tt.data)}}
+
+			cfg, err := LoadConfig(fsys, "user.json")
+			if cfg != nil {
+				t.Errorf("LoadConfig() config = %+v; want nil", *cfg)
+			}
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("LoadConfig() = %v; want a *ConfigError", err)
+			}
+			if configErr.Filename != "user.json" || configErr.Line != tt.line || configErr.Column != tt.column || configErr.Key != tt.key {
+				t.Errorf("ConfigError = %s:%d:%d key %q; want user.json:%d:%d key %q",
+					configErr.Filename, configErr.Line, configErr.Column, configErr.Key, tt.line, tt.column, tt.key)
+			}
+			if !errors.As(err, tt.expected) {
+				t.Errorf("LoadConfig() = %v; want it to wrap %T", err, tt.expected)
+			}
+			if Fields(err)["filename"] != "user.json" || len(StackTrace(err)) == 0 {
+				t.Error("LoadConfig parse errors should carry the filename field and a stack")
+			}
+		})
+	}
+}
+
 func TestErrorChain_UnwrapBehavior(t *testing.T) {
 	err := ProcessUserData(999)
 
 	// Test that we can traverse the error chain
 	var levels []string
-	currentErr := err
-
-	for currentErr != nil {
-		levels = append(levels, currentErr.Error())
-
-		// Try to unwrap
-		if unwrapper, ok := currentErr.(interface{ Unwrap() error }); ok {
-			currentErr = unwrapper.Unwrap()
-		} else {
-			break
-		}
+	for current := range All(err) {
+		levels = append(levels, current.Error())
 	}
 
 	// Should have multiple levels